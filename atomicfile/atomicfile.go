@@ -0,0 +1,149 @@
+// Package atomicfile writes files through a temporary file in the same directory, which is synced and renamed
+// over the target, so that readers and crashes see either the old content or the new one but never a partial file.
+package atomicfile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DefaultPerm  fs.FileMode = 0644
+	BackupSuffix             = ".bak"
+)
+
+type Options struct {
+	// Perm is the permission of a new file. An existing file keeps its own. Zero means DefaultPerm.
+	Perm fs.FileMode
+	// Backup keeps the previous content of an existing file as the file name followed by BackupSuffix.
+	Backup bool
+}
+
+// File is a pending write of a file. The target is replaced only by Commit, and left untouched by Abort.
+type File struct {
+	name    string
+	temp    *os.File
+	perm    fs.FileMode
+	backup  bool
+	existed bool
+	done    bool
+}
+
+// Create starts writing name. When name is a symbolic link, the file it points to is replaced.
+func Create(name string, options Options) (*File, error) {
+	perm := options.Perm
+	if perm == 0 {
+		perm = DefaultPerm
+	}
+
+	existed := false
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		name = resolved
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			return nil, &fs.PathError{Op: "create", Path: name, Err: errors.New("not a regular file")}
+		}
+		perm, existed = info.Mode().Perm(), true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{name: name, temp: temp, perm: perm, backup: options.Backup && existed, existed: existed}, nil
+}
+
+func (f *File) Name() string {
+	return f.name
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	return f.temp.Write(p)
+}
+
+// Commit syncs the written content and renames it over the target.
+func (f *File) Commit() (err error) {
+	if f.done {
+		return os.ErrClosed
+	}
+	f.done = true
+
+	defer func() {
+		if err != nil {
+			os.Remove(f.temp.Name())
+		}
+	}()
+
+	if err = f.temp.Chmod(f.perm); err != nil {
+		f.temp.Close()
+		return err
+	}
+	if err = f.temp.Sync(); err != nil {
+		f.temp.Close()
+		return err
+	}
+	if err = f.temp.Close(); err != nil {
+		return err
+	}
+
+	if f.backup {
+		if err = backup(f.name, f.perm); err != nil {
+			return err
+		}
+	}
+
+	if err = os.Rename(f.temp.Name(), f.name); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(f.name))
+	return nil
+}
+
+// Abort discards the written content. It does nothing after Commit, so it can be deferred.
+func (f *File) Abort() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	f.temp.Close()
+	return os.Remove(f.temp.Name())
+}
+
+func backup(name string, perm fs.FileMode) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return WriteFile(name+BackupSuffix, data, Options{Perm: perm})
+}
+
+// syncDir makes the rename durable. It is best-effort, as directories can not be synced on every platform.
+func syncDir(dir string) {
+	if file, err := os.Open(dir); err == nil {
+		file.Sync()
+		file.Close()
+	}
+}
+
+// WriteFile is like os.WriteFile, but replaces name atomically.
+func WriteFile(name string, data []byte, options Options) error {
+	file, err := Create(name, options)
+	if err != nil {
+		return err
+	}
+	defer file.Abort()
+
+	if _, err = file.Write(data); err != nil {
+		return err
+	}
+	return file.Commit()
+}
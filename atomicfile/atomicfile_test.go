@@ -0,0 +1,122 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entriesOf(t *testing.T, dir string) (names []string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Run("new file", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "versioninfo.json")
+
+		require.NoError(t, WriteFile(name, []byte("new"), Options{}))
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+		assert.Equal(t, []string{"versioninfo.json"}, entriesOf(t, dir))
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(name)
+			require.NoError(t, err)
+			assert.Equal(t, DefaultPerm, info.Mode().Perm())
+		}
+	})
+
+	t.Run("keeps mode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes are not supported")
+		}
+
+		name := filepath.Join(t.TempDir(), "versioninfo.json")
+		require.NoError(t, os.WriteFile(name, []byte("old"), 0600))
+		require.NoError(t, os.Chmod(name, 0600))
+
+		require.NoError(t, WriteFile(name, []byte("new"), Options{Perm: 0644}))
+
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "versioninfo.json")
+		require.NoError(t, os.WriteFile(name, []byte("old"), 0644))
+
+		require.NoError(t, WriteFile(name, []byte("new"), Options{Backup: true}))
+
+		data, err := os.ReadFile(name + BackupSuffix)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(data))
+		data, err = os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("no backup for new file", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "versioninfo.json")
+
+		require.NoError(t, WriteFile(name, []byte("new"), Options{Backup: true}))
+		assert.Equal(t, []string{"versioninfo.json"}, entriesOf(t, dir))
+	})
+
+	t.Run("symbolic link", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "target.json")
+		link := filepath.Join(dir, "link.json")
+		require.NoError(t, os.WriteFile(target, []byte("old"), 0644))
+		if err := os.Symlink(target, link); err != nil {
+			t.Skip("symbolic links are not supported")
+		}
+
+		require.NoError(t, WriteFile(link, []byte("new"), Options{}))
+
+		data, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+		info, err := os.Lstat(link)
+		require.NoError(t, err)
+		assert.Equal(t, os.ModeSymlink, info.Mode().Type())
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		assert.Error(t, WriteFile(t.TempDir(), []byte("new"), Options{}))
+	})
+}
+
+func TestAbort(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "versioninfo.json")
+	require.NoError(t, os.WriteFile(name, []byte("old"), 0644))
+
+	file, err := Create(name, Options{})
+	require.NoError(t, err)
+	_, err = file.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.NoError(t, file.Abort())
+
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	assert.Equal(t, []string{"versioninfo.json"}, entriesOf(t, dir))
+
+	assert.ErrorIs(t, file.Commit(), os.ErrClosed)
+}
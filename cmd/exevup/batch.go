@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+const recursiveSuffix = "..."
+
+// isSkippedDir reports whether a directory is skipped by recursive discovery, as the go command does.
+func isSkippedDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata"
+}
+
+// isVersionInfoFile reports whether name is versioninfo with the extension of a format, e.g. versioninfo.yaml.
+func isVersionInfoFile(name string) bool {
+	baseName := strings.TrimSuffix(defaultFileName, filepath.Ext(defaultFileName))
+	return strings.TrimSuffix(name, filepath.Ext(name)) == baseName && model.HasFormatExtension(name)
+}
+
+// findVersionInfoFiles returns the version info files under dir, e.g. versioninfo.json or versioninfo.yaml.
+func findVersionInfoFiles(dir string) (result []string, err error) {
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && isSkippedDir(entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isVersionInfoFile(entry.Name()) {
+			result = append(result, path)
+		}
+		return nil
+	})
+	return
+}
+
+// expandFileNames returns the files given by patterns, which are file names, globs such as cmd/*/versioninfo.json,
+// or directories followed by /... to find every version info file under them.
+func expandFileNames(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return []string{defaultFileName}, nil
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		var matches []string
+		var err error
+
+		switch dir, recursive := strings.CutSuffix(filepath.ToSlash(pattern), recursiveSuffix); {
+		case recursive && (dir == "" || strings.HasSuffix(dir, "/")):
+			dir = strings.TrimSuffix(dir, "/")
+			if dir == "" {
+				dir = "."
+			}
+			if matches, err = findVersionInfoFiles(filepath.FromSlash(dir)); err == nil && len(matches) == 0 {
+				err = fmt.Errorf("no version info file found in %s", pattern)
+			}
+		case strings.ContainsAny(pattern, "*?["):
+			if matches, err = filepath.Glob(pattern); err == nil && len(matches) == 0 {
+				err = fmt.Errorf("no file matches %s", pattern)
+			}
+		default:
+			matches = []string{pattern}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range matches {
+			if !seen[filepath.Clean(match)] {
+				seen[filepath.Clean(match)] = true
+				result = append(result, match)
+			}
+		}
+	}
+	return result, nil
+}
+
+// forEachFile runs fn for every file concurrently and returns the results and errors in the order of fileNames.
+func forEachFile[T any](fileNames []string, fn func(fileName string) (T, error)) ([]T, []error) {
+	results := make([]T, len(fileNames))
+	errs := make([]error, len(fileNames))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, fileName := range fileNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i], errs[i] = fn(fileName)
+		}()
+	}
+	wg.Wait()
+
+	return results, errs
+}
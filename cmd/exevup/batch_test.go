@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTree(t *testing.T, fileNames ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, fileName := range fileNames {
+		path := filepath.Join(dir, filepath.FromSlash(fileName))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+	}
+	return dir
+}
+
+func TestExpandFileNames(t *testing.T) {
+	dir := newTree(t,
+		"cmd/a/versioninfo.json",
+		"cmd/b/versioninfo.json",
+		"cmd/b/other.json",
+		"cmd/c/versioninfo.jsonc",
+		"cmd/c/versioninfo.yaml",
+		"cmd/c/versioninfo.txt",
+		"cmd/.hidden/versioninfo.json",
+		"cmd/testdata/versioninfo.json",
+		"versioninfo.json",
+		"docs/readme.md",
+	)
+	a := filepath.Join(dir, "cmd", "a", "versioninfo.json")
+	b := filepath.Join(dir, "cmd", "b", "versioninfo.json")
+	c := filepath.Join(dir, "cmd", "c", "versioninfo.jsonc")
+	d := filepath.Join(dir, "cmd", "c", "versioninfo.yaml")
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+		hasError bool
+	}{
+		{name: "default", expected: []string{defaultFileName}},
+		{name: "file names", patterns: []string{a, b}, expected: []string{a, b}},
+		{name: "glob", patterns: []string{filepath.Join(dir, "cmd", "[ab]", "versioninfo.json")}, expected: []string{a, b}},
+		{name: "recursive", patterns: []string{filepath.Join(dir, "cmd") + "/..."}, expected: []string{a, b, c, d}},
+		{name: "recursive root", patterns: []string{dir + "/..."}, expected: []string{a, b, c, d, filepath.Join(dir, "versioninfo.json")}},
+		{name: "duplicates", patterns: []string{a, filepath.Join(dir, "cmd", "[ab]", "*.json")}, expected: []string{a, filepath.Join(dir, "cmd", "b", "other.json"), b}},
+		{name: "glob without match", patterns: []string{filepath.Join(dir, "*.yaml")}, hasError: true},
+		{name: "recursive without match", patterns: []string{filepath.Join(dir, "docs") + "/..."}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandFileNames(tt.patterns)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestForEachFile(t *testing.T) {
+	fileNames := []string{"a", "b", "c", "d"}
+
+	results, errs := forEachFile(fileNames, func(fileName string) (string, error) {
+		if fileName == "c" {
+			return "", errors.New("failed")
+		}
+		return fileName + fileName, nil
+	})
+
+	assert.Equal(t, []string{"aa", "bb", "", "dd"}, results)
+	assert.Equal(t, []error{nil, nil, errors.New("failed"), nil}, errs)
+}
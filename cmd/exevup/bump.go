@@ -0,0 +1,543 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simp7/goversioninfo-toolkit/conventional"
+	"github.com/simp7/goversioninfo-toolkit/gitversion"
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/simp7/goversioninfo-toolkit/workspace"
+)
+
+type bumpOptions struct {
+	notation        model.VersionNotation
+	level           model.VersionLevel
+	target          model.VersionTarget
+	fileLevel       model.VersionLevel
+	productLevel    model.VersionLevel
+	fileNotation    model.VersionNotation
+	productNotation model.VersionNotation
+	changes         []model.ComponentChange
+	commit          string
+	preRelease      string
+	metadata        string
+	scheme          string
+	date            string
+	tagPrefix       string
+	dir             string
+}
+
+// updates returns how each target is bumped. Level applies to the targets of target, and
+// fileLevel and productLevel override it for their own target regardless of target, as do the notations.
+func (o bumpOptions) updates() (file model.TargetUpdate, product model.TargetUpdate) {
+	file = model.TargetUpdate{Notation: o.notation, PreRelease: o.preRelease, Metadata: o.metadata, Commit: o.commit}
+	product = file
+
+	if o.target != model.TargetProduct {
+		file.Level = o.level
+	}
+	if o.target != model.TargetFile {
+		product.Level = o.level
+	}
+
+	if o.fileLevel != "" {
+		file.Level = o.fileLevel
+	}
+	if o.productLevel != "" {
+		product.Level = o.productLevel
+	}
+	if o.fileNotation != "" {
+		file.Notation = o.fileNotation
+	}
+	if o.productNotation != "" {
+		product.Notation = o.productNotation
+	}
+	return
+}
+
+const (
+	schemeGit = "git"
+	levelAuto = "auto"
+)
+
+func calVerSchemeOf(value string) (model.CalVerScheme, error) {
+	pattern, ok := strings.CutPrefix(value, "calver:")
+	if !ok {
+		return model.CalVerScheme{}, fmt.Errorf("unknown scheme %q", value)
+	}
+	return model.ParseCalVerScheme(pattern)
+}
+
+// now returns the time of SOURCE_DATE_EPOCH when it is set, so that builds are reproducible, or the current time.
+func now() (time.Time, error) {
+	epoch, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok {
+		return time.Now(), nil
+	}
+
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q, expected seconds since the Unix epoch", epoch)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+func dateOf(value string) (time.Time, error) {
+	if value == "" {
+		return now()
+	}
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}
+
+// gitVersionUpdated sets the version of the latest release tag with the number of commits since it added to build.
+func gitVersionUpdated(info model.Info, options bumpOptions) (model.Info, error) {
+	description, err := gitversion.Describe(options.dir, options.tagPrefix)
+	if err != nil {
+		return info, err
+	}
+
+	version := description.Next()
+	if err = version.CheckRange(); err != nil {
+		return info, err
+	}
+	file, product := options.updates()
+	if options.target != model.TargetProduct {
+		info = info.FileVersionUpdated(version, file.Notation)
+	}
+	if options.target != model.TargetFile {
+		info = info.ProductVersionUpdated(version, product.Notation)
+	}
+	return info, nil
+}
+
+// autoLevelOf chooses the level from the Conventional Commits since the latest release tag and prints the reasoning to w.
+func autoLevelOf(w io.Writer, dir string, tagPrefix string) (model.VersionLevel, error) {
+	messages, err := gitversion.Messages(dir, tagPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	level, reasons := conventional.Decide(messages)
+	if len(reasons) == 0 {
+		fmt.Fprintf(w, "level %s, no commit of %d since the latest release tag requires a release\n", level, len(messages))
+		return level, nil
+	}
+
+	fmt.Fprintf(w, "level %s, from %d of %d commits since the latest release tag\n", level, len(reasons), len(messages))
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "  %-6s %s\n", reason.Level, reason.Commit)
+	}
+	return level, nil
+}
+
+// autoLevelResolved replaces the levels which are auto by the level chosen from the commits, printing the reasoning to w.
+func (o bumpOptions) autoLevelResolved(w io.Writer) (bumpOptions, error) {
+	if o.level != levelAuto && o.fileLevel != levelAuto && o.productLevel != levelAuto {
+		return o, nil
+	}
+
+	level, err := autoLevelOf(w, o.dir, o.tagPrefix)
+	if err != nil {
+		return o, err
+	}
+
+	for _, target := range []*model.VersionLevel{&o.level, &o.fileLevel, &o.productLevel} {
+		if *target == levelAuto {
+			*target = level
+		}
+	}
+	return o, nil
+}
+
+// commitResolved fills the commit for template notations from the repository containing the file, when it is not given.
+// Outside of a repository, the commit is left empty.
+func (o bumpOptions) commitResolved() bumpOptions {
+	if o.commit != "" || !(o.notation.IsTemplate() || o.fileNotation.IsTemplate() || o.productNotation.IsTemplate()) {
+		return o
+	}
+
+	o.commit, _ = gitversion.Commit(o.dir)
+	return o
+}
+
+func bumpedInfo(info model.Info, options bumpOptions) (model.Info, error) {
+	if len(options.changes) > 0 {
+		return info.ComponentsChanged(options.changes, options.target, options.notation)
+	}
+
+	if options.scheme == schemeGit {
+		return gitVersionUpdated(info, options)
+	}
+
+	if options.scheme != "" {
+		scheme, err := calVerSchemeOf(options.scheme)
+		if err != nil {
+			return info, err
+		}
+
+		date, err := dateOf(options.date)
+		if err != nil {
+			return info, err
+		}
+
+		return info.CalVerUpdated(scheme, date, options.target)
+	}
+
+	file, product := options.updates()
+	if file.Notation.IsTemplate() || product.Notation.IsTemplate() {
+		date, err := dateOf(options.date)
+		if err != nil {
+			return info, err
+		}
+		file.Date, product.Date = date, date
+	}
+	return info.LevelsUpdated(file, product)
+}
+
+// bumpResult is the bumped or synced content of a file, which is not written yet.
+type bumpResult struct {
+	inputFileName  string
+	outputFileName string
+	original       []byte
+	data           []byte
+	before         model.Info
+	after          model.Info
+	log            bytes.Buffer
+}
+
+// report returns the result of the file for -output-format json, without the written files.
+func (r *bumpResult) report(err error) fileReport {
+	result := fileReport{Input: r.inputFileName, Output: r.outputFileName, Warnings: warningsOf(r.log.String())}
+	if err != nil {
+		result.Error = errorReportOf(err)
+		return result
+	}
+
+	result.Before, result.After = versionReportOf(r.before), versionReportOf(r.after)
+	return result
+}
+
+// prepareBump reads inputFileName and returns its bumped content. Level may be auto, in which case
+// the level is chosen from the repository containing inputFileName.
+func (a app) prepareBump(inputFileName string, outputFileName string, options bumpOptions, create bool, reformat bool) (result *bumpResult, err error) {
+	result = &bumpResult{inputFileName: inputFileName, outputFileName: outputFileName}
+	if result.original, result.before, err = a.readVersionInfo(inputFileName, create); err != nil {
+		return
+	}
+
+	options.dir = filepath.Dir(inputFileName)
+	if options, err = options.autoLevelResolved(&result.log); err != nil {
+		return
+	}
+	options = options.commitResolved()
+
+	if result.after, err = bumpedInfo(result.before, options); err != nil {
+		return
+	}
+
+	result.data, err = a.versionInfoDataOf(inputFileName, outputFileName, result.original, result.after, reformat)
+	return
+}
+
+// versionChangeOf describes the change of the version of target, e.g. 1.2.3 -> 1.2.4.
+func versionChangeOf(before model.Info, after model.Info, target model.VersionTarget) string {
+	versionString := func(info model.Info) string {
+		if target == model.TargetProduct {
+			return versionStringOf(info.StringFileInfo.ProductVersion, model.Version(info.FixedFileInfo.ProductVersion), false)
+		}
+		return versionStringOf(info.StringFileInfo.FileVersion, model.Version(info.FixedFileInfo.FileVersion), false)
+	}
+	return fmt.Sprintf("%s -> %s", versionString(before), versionString(after))
+}
+
+func (a app) bump(args []string) error {
+	fs := a.newFlagSet("bump", "exevup [bump] [flags] [file...]", "Bump the version of files, which is versioninfo.json by default.\n"+
+		"Files can be globs such as cmd/*/versioninfo.json, or dir/... for every versioninfo.json, .yaml, .yml or .toml under dir.\n"+
+		"Files are bumped concurrently, and none is written when any of them fails.\n"+
+		"Run 'exevup help' for other commands.")
+
+	notationValue := fs.String("notation", string(model.NotationNormal), "notation for version - simple/normal/detail/semver, or template:{text/template} e.g. template:{{.Major}}.{{.Minor}} (build {{.Build}})")
+	fs.StringVar(notationValue, "n", *notationValue, "alias for -notation")
+
+	levelValue := fs.String("level", string(model.LevelPatch), "level for versioning - major/minor/patch/build, prerelease/release for semver notation, or auto from conventional commits")
+	fs.StringVar(levelValue, "l", *levelValue, "alias for -level")
+
+	targetValue := fs.String("target", string(model.TargetBoth), "target for versioning - both/file/product")
+	fs.StringVar(targetValue, "t", *targetValue, "alias for -target")
+
+	fileLevel := fs.String("file-level", "", "level for the file version, overriding -level and -target")
+	productLevel := fs.String("product-level", "", "level for the product version, overriding -level and -target")
+	fileNotation := fs.String("file-notation", "", "notation for the file version, overriding -notation")
+	productNotation := fs.String("product-notation", "", "notation for the product version, overriding -notation")
+
+	outputName := fs.String("output", "", "output file name for a single file, - for stdout, blank for input itself")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	preRelease := fs.String("pre", "", "pre-release identifier to start for semver notation, e.g. rc, or .PreRelease of template notations")
+	metadata := fs.String("metadata", "", "build metadata for semver notation, e.g. sha.abc123")
+
+	schemeValue := fs.String("scheme", "", "versioning scheme instead of level, e.g. calver:YYYY.0M.0D.N, or git for the latest release tag")
+	fs.StringVar(schemeValue, "s", *schemeValue, "alias for -scheme")
+
+	changes := a.componentChangeFlags(fs)
+
+	dateValue := fs.String("date", "", "date for calver scheme in YYYY-MM-DD, blank for today")
+	tagPrefix := fs.String("tag-prefix", "v", "prefix of release tags for git scheme and auto level")
+	commit := fs.String("commit", "", "commit for template notations, default is HEAD of the repository containing the file")
+
+	syso := a.sysoFlags(fs)
+
+	create := fs.Bool("create", false, "start from the default version info when file does not exist")
+
+	reformat := fs.Bool("reformat", false, "rewrite the whole file instead of only the changed values")
+	backup := fs.Bool("backup", false, "keep the previous content of the output file as .bak")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a unified diff instead of writing any file")
+
+	workspaceName := fs.String("workspace", "", "workspace config whose canonical version is bumped and synced, default is "+workspace.FileName+" when no file is given and it exists")
+	fs.StringVar(workspaceName, "w", *workspaceName, "alias for -workspace")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	inputFileNames, err := expandFileNames(positional)
+	if err != nil {
+		return err
+	}
+	batch := len(inputFileNames) > 1
+
+	if batch && *outputName != "" {
+		return a.usageError(fs, "-output can not be used with more than one file")
+	}
+	if err = a.checkStdio(fs, inputFileNames, *outputName); err != nil {
+		return err
+	}
+	if batch && filepath.IsAbs(syso.fileName) {
+		return a.usageError(fs, "-syso must be relative to each file with more than one file")
+	}
+	if len(*changes) > 0 {
+		for _, name := range []string{"level", "l", "file-level", "product-level", "scheme", "s"} {
+			if isFlagSet(fs, name) {
+				return a.usageError(fs, "-set, -increment and -decrement can not be used with -%s", name)
+			}
+		}
+	}
+
+	target, err := a.targetOf(fs, *targetValue)
+	if err != nil {
+		return err
+	}
+	level, err := a.levelOf(fs, *levelValue)
+	if err != nil {
+		return err
+	}
+	notation, err := a.notationOf(fs, *notationValue)
+	if err != nil {
+		return err
+	}
+	//비어 있는 대상별 값은 -level과 -notation을 따름
+	for _, value := range []string{*fileLevel, *productLevel} {
+		if value == "" {
+			continue
+		}
+		if _, err = a.levelOf(fs, value); err != nil {
+			return err
+		}
+	}
+	for _, value := range []string{*fileNotation, *productNotation} {
+		if value == "" {
+			continue
+		}
+		if _, err = a.notationOf(fs, value); err != nil {
+			return err
+		}
+	}
+
+	archs, err := a.archsOf(fs, syso.archs)
+	if err != nil {
+		return err
+	}
+
+	options := bumpOptions{
+		notation:        notation,
+		level:           level,
+		target:          target,
+		fileLevel:       model.VersionLevel(*fileLevel),
+		productLevel:    model.VersionLevel(*productLevel),
+		fileNotation:    model.VersionNotation(*fileNotation),
+		productNotation: model.VersionNotation(*productNotation),
+		changes:         *changes,
+		commit:          *commit,
+		preRelease:      *preRelease,
+		metadata:        *metadata,
+		scheme:          *schemeValue,
+		date:            *dateValue,
+		tagPrefix:       *tagPrefix,
+	}
+
+	a.reportOptions(target, options.notation, *dryRun)
+
+	if *workspaceName != "" || (len(positional) == 0 && fileExists(workspace.FileName)) {
+		if len(positional) > 0 || *outputName != "" {
+			return a.usageError(fs, "files and -output can not be used with a workspace")
+		}
+		if *fileLevel != "" || *productLevel != "" {
+			return a.usageError(fs, "a workspace has a single version, so -file-level and -product-level can not be used")
+		}
+		//정규 버전은 다시 읽을 수 있어야 하므로 템플릿은 설정 파일에서 파일별로만 씀
+		if options.notation.IsTemplate() || options.fileNotation.IsTemplate() || options.productNotation.IsTemplate() {
+			return a.usageError(fs, "template notations can not be used with a workspace, give them per file in the workspace config")
+		}
+		if *workspaceName == "" {
+			*workspaceName = workspace.FileName
+		}
+		return a.bumpWorkspace(*workspaceName, options, isFlagSet(fs, "notation") || isFlagSet(fs, "n"), *reformat, commitOptions{
+			sysoFileName: syso.fileName,
+			archs:        archs,
+			target:       target,
+			batch:        true,
+			backup:       *backup,
+			dryRun:       *dryRun,
+		})
+	}
+
+	results, errs := forEachFile(inputFileNames, func(inputFileName string) (*bumpResult, error) {
+		outputFileName := inputFileName
+		if *outputName != "" {
+			outputFileName = *outputName
+		}
+		return a.prepareBump(inputFileName, outputFileName, options, *create, *reformat)
+	})
+
+	return a.commitResults(results, errs, nil, commitOptions{
+		sysoFileName: syso.fileName,
+		archs:        archs,
+		target:       target,
+		batch:        batch,
+		backup:       *backup,
+		dryRun:       *dryRun,
+	})
+}
+
+type commitOptions struct {
+	sysoFileName string
+	archs        []string
+	target       model.VersionTarget
+	batch        bool
+	backup       bool
+	dryRun       bool
+	// changeOf describes the change of each file in the summary, which is the change of the version of target by default.
+	changeOf func(before model.Info, after model.Info) string
+}
+
+// commitResults writes results with extra files such as the workspace config, or none of them when any result failed.
+// In batch, the log of each result is prefixed with its file name and a summary is printed per file.
+func (a app) commitResults(results []*bumpResult, errs []error, extra []pendingWrite, options commitOptions) (err error) {
+	reports := make([]fileReport, len(results))
+	for i, result := range results {
+		reports[i] = result.report(errs[i])
+	}
+	defer func() {
+		for _, report := range reports {
+			a.reportFile(report)
+		}
+	}()
+
+	failed := 0
+	for i, result := range results {
+		for _, line := range strings.SplitAfter(result.log.String(), "\n") {
+			if line != "" && options.batch {
+				line = result.inputFileName + ": " + line
+			}
+			fmt.Fprint(a.stderr, line)
+		}
+		if errs[i] != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		if !options.batch {
+			return errs[0]
+		}
+		for i, result := range results {
+			if errs[i] != nil {
+				fmt.Fprintf(a.stderr, "%s: %s\n", result.inputFileName, errs[i])
+			}
+		}
+		return fmt.Errorf("%d of %d files failed, nothing was written", failed, len(results))
+	}
+
+	if options.dryRun {
+		for i, result := range results {
+			reports[i].Diff = unifiedDiff(result.inputFileName, result.outputFileName, result.original, result.data)
+			if _, err = fmt.Fprint(a.stdout, reports[i].Diff); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	//표준 출력에 쓰는 경우 출력이 섞이지 않도록 요약은 표준 에러에 씀
+	var stdout []byte
+	summary := a.stdout
+	writes := make([]pendingWrite, 0, len(results)+len(extra))
+	sysoFileNames := make([][]string, len(results))
+	for i, result := range results {
+		//여러 파일을 처리할 때는 -syso를 각 파일의 디렉토리 기준으로 씀
+		sysoFileName := options.sysoFileName
+		if options.batch && sysoFileName != "" {
+			sysoFileName = filepath.Join(filepath.Dir(result.inputFileName), sysoFileName)
+		}
+		syso, err := sysoWrites(result.after, filepath.Dir(result.inputFileName), sysoFileName, options.archs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", result.inputFileName, err)
+		}
+		for _, write := range syso {
+			sysoFileNames[i] = append(sysoFileNames[i], write.fileName)
+		}
+		writes = append(writes, syso...)
+
+		if result.outputFileName == stdio {
+			stdout, summary = result.data, a.stderr
+			continue
+		}
+		writes = append(writes, pendingWrite{fileName: result.outputFileName, data: result.data})
+	}
+
+	if err = writeVersionInfoFiles(append(writes, extra...), options.backup); err != nil {
+		return err
+	}
+	if stdout != nil {
+		if _, err = a.stdout.Write(stdout); err != nil {
+			return err
+		}
+	}
+
+	for i, result := range results {
+		reports[i].Written = append([]string{result.outputFileName}, sysoFileNames[i]...)
+	}
+
+	if options.batch {
+		changeOf := options.changeOf
+		if changeOf == nil {
+			changeOf = func(before model.Info, after model.Info) string {
+				return versionChangeOf(before, after, options.target)
+			}
+		}
+		for _, result := range results {
+			fmt.Fprintf(summary, "%s: %s\n", result.inputFileName, changeOf(result.before, result.after))
+		}
+	}
+	return nil
+}
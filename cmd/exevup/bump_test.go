@@ -0,0 +1,405 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpOptionsUpdates(t *testing.T) {
+	options := bumpOptions{level: model.LevelMinor, target: model.TargetProduct, notation: model.NotationNormal}
+	file, product := options.updates()
+	assert.Equal(t, model.VersionLevel(""), file.Level)
+	assert.Equal(t, model.LevelMinor, product.Level)
+
+	options.fileLevel = model.LevelBuild
+	options.fileNotation = model.NotationDetail
+	file, product = options.updates()
+	assert.Equal(t, model.TargetUpdate{Level: model.LevelBuild, Notation: model.NotationDetail}, file)
+	assert.Equal(t, model.TargetUpdate{Level: model.LevelMinor, Notation: model.NotationNormal}, product)
+}
+
+func TestCalVerSchemeOf(t *testing.T) {
+	scheme, err := calVerSchemeOf("calver:YYYY.0M.0D.N")
+	require.NoError(t, err)
+	assert.Equal(t, "YYYY.0M.0D.N", scheme.String())
+
+	_, err = calVerSchemeOf("YYYY.0M.0D.N")
+	assert.Error(t, err)
+
+	_, err = calVerSchemeOf("calver:YYYY.N.MM")
+	assert.ErrorIs(t, err, model.ErrInvalidCalVerScheme)
+}
+
+func TestDateOf(t *testing.T) {
+	date, err := dateOf("2024-03-05")
+	require.NoError(t, err)
+	assert.Equal(t, 2024, date.Year())
+	assert.Equal(t, time.March, date.Month())
+	assert.Equal(t, 5, date.Day())
+
+	_, err = dateOf("05/03/2024")
+	assert.Error(t, err)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1709596800")
+	date, err = dateOf("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), date)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	_, err = dateOf("")
+	assert.Error(t, err)
+}
+
+func TestBumpPreservesFormatting(t *testing.T) {
+	original := `{
+  "StringFileInfo": {
+    "FileVersion": "1.2.3",
+    "ProductVersion": "1.2.3",
+    "X-Custom": "kept"
+  }
+}
+`
+	fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(original), 0644))
+
+	code, _, stderr := runApp("bump", "-t", "file", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "StringFileInfo": {
+    "FileVersion": "1.2.4",
+    "ProductVersion": "1.2.3",
+    "X-Custom": "kept"
+  },
+  "FixedFileInfo": {
+    "FileVersion": {
+      "Major": 1,
+      "Minor": 2,
+      "Patch": 4
+    }
+  }
+}
+`, string(data))
+
+	code, _, stderr = runApp("bump", "-reformat", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	data, err = os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "X-Custom")
+	assert.Contains(t, string(data), "\t\"FixedFileInfo\"")
+}
+
+// newGitVersionInfoFile creates a version info file in a new repository with the given commits after tag.
+func newGitVersionInfoFile(t *testing.T, tag string, messages ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	fileName := newVersionInfoFile(t, "0.0.1")
+	commands := [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"commit", "-q", "-m", "chore: init"},
+		{"tag", tag},
+	}
+	for _, message := range messages {
+		commands = append(commands, []string{"commit", "-q", "--allow-empty", "-m", message})
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command("git", append([]string{"-C", filepath.Dir(fileName), "-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	return fileName
+}
+
+func TestBumpGitScheme(t *testing.T) {
+	fileName := newGitVersionInfoFile(t, "v1.4.0", "second")
+
+	code, _, stderr := runApp("bump", "-s", "git", "-n", "detail", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info := readVersionInfoFile(t, fileName)
+	assert.Equal(t, "1.4.0.1", info.StringFileInfo.FileVersion)
+	assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 1}, model.Version(info.FixedFileInfo.ProductVersion))
+
+	code, _, stderr = runApp("bump", "-s", "git", "-tag-prefix", "release-", fileName)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "no release tag")
+
+	fileName = newGitVersionInfoFile(t, "v70000.0.0")
+	code, _, stderr = runApp("bump", "-s", "git", fileName)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "does not fit in 16 bits")
+	assert.Equal(t, "0.0.1", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+}
+
+func TestBumpAutoLevel(t *testing.T) {
+	t.Run("feature", func(t *testing.T) {
+		fileName := newGitVersionInfoFile(t, "v1.2.3", "fix: crash", "feat(cli): add flag", "docs: readme")
+
+		code, _, stderr := runApp("bump", "-l", "auto", fileName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "0.1.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+		assert.Contains(t, stderr, "level minor, from 2 of 3 commits")
+		assert.Contains(t, stderr, "  minor  feat(cli): add flag\n")
+		assert.Contains(t, stderr, "  patch  fix: crash\n")
+	})
+
+	t.Run("nothing to release", func(t *testing.T) {
+		fileName := newGitVersionInfoFile(t, "v1.2.3", "docs: readme")
+
+		code, _, stderr := runApp("bump", "-l", "auto", "-n", "detail", fileName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "0.0.1.1", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+		assert.Contains(t, stderr, "level build")
+	})
+}
+
+func TestBumpIndependentLevels(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+
+	code, _, stderr := runApp("bump", "-file-level", "build", "-file-notation", "detail", "-product-level", "minor", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info := readVersionInfoFile(t, fileName)
+	assert.Equal(t, "1.2.3.1", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "1.3.0", info.StringFileInfo.ProductVersion)
+
+	code, _, stderr = runApp("bump", "-t", "file", "-product-level", "patch", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info = readVersionInfoFile(t, fileName)
+	assert.Equal(t, "1.2.4", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "1.3.1", info.StringFileInfo.ProductVersion)
+}
+
+func TestBumpComponents(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+
+	code, _, stderr := runApp("bump", "-set", "build=1234", "-increment", "minor", "-n", "detail", fileName)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "1.3.3.1234", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+
+	code, _, stderr = runApp("bump", "-decrement", "patch=4", "-n", "detail", fileName)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "does not fit in 16 bits")
+
+	code, _, stderr = runApp("bump", "-set", "build=1", "-l", "minor", fileName)
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "can not be used with -l")
+}
+
+func TestBumpTemplateNotation(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+
+	code, _, stderr := runApp("bump", "-l", "minor", "-t", "product", "-n", "template:v{{.Major}}.{{.Minor}}-{{.Commit}} {{.Date.Format \"2006\"}}", "-commit", "abc1234", "-date", "2024-03-05", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info := readVersionInfoFile(t, fileName)
+	assert.Equal(t, "1.2.3", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "v1.3-abc1234 2024", info.StringFileInfo.ProductVersion)
+
+	code, _, _ = runApp("bump", "-n", "template:{{.Major", fileName)
+	assert.Equal(t, exitFailure, code)
+}
+
+func TestBumpDryRun(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	original, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	sysoFileName := filepath.Join(filepath.Dir(fileName), "resource.syso")
+
+	code, stdout, stderr := runApp("bump", "-dry-run", "-t", "file", "-syso", sysoFileName, fileName)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, "--- "+fileName+"\n+++ "+fileName+"\n")
+	assert.Contains(t, stdout, "-\t\t\"FileVersion\": \"1.2.3\",\n+\t\t\"FileVersion\": \"1.2.4\",\n")
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(data))
+	assert.NoFileExists(t, sysoFileName)
+}
+
+func TestBumpBackup(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	original, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	code, _, stderr := runApp("bump", "-backup", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	backup, err := os.ReadFile(fileName + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(backup))
+	assert.Equal(t, "1.2.4", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+}
+
+func TestBumpMissingFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+
+	code, _, stderr := runApp("bump", fileName)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "file not found")
+	assert.NoFileExists(t, fileName)
+
+	code, _, stderr = runApp("bump", "-create", "-l", "minor", fileName)
+	require.Equal(t, exitOK, code, stderr)
+	info := readVersionInfoFile(t, fileName)
+	assert.Equal(t, "1.1.0", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "040004", info.FixedFileInfo.FileOS)
+}
+
+func TestBumpFormats(t *testing.T) {
+	tests := []struct {
+		fileName string
+		original string
+		expected string
+	}{
+		{
+			fileName: "versioninfo.yaml",
+			original: "# release of the tool\nStringFileInfo:\n  FileVersion: 1.2.3 # bumped by CI\n",
+			expected: "# release of the tool\nStringFileInfo:\n  FileVersion: 1.3.0 # bumped by CI\n",
+		},
+		{
+			fileName: "versioninfo.jsonc",
+			original: "{\n\t// release of the tool\n\t\"StringFileInfo\": {\n\t\t\"FileVersion\": \"1.2.3\", // bumped by CI\n\t},\n}\n",
+			expected: "{\n\t// release of the tool\n\t\"StringFileInfo\": {\n\t\t\"FileVersion\": \"1.3.0\", // bumped by CI\n\t},",
+		},
+		{
+			fileName: "versioninfo.toml",
+			original: "# release of the tool\n[StringFileInfo]\nFileVersion = \"1.2.3\" # bumped by CI\n",
+			expected: "# release of the tool\n[StringFileInfo]\nFileVersion = \"1.3.0\" # bumped by CI\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), tt.fileName)
+			require.NoError(t, os.WriteFile(fileName, []byte(tt.original), 0644))
+
+			code, _, stderr := runApp("bump", "-l", "minor", "-t", "file", fileName)
+			require.Equal(t, exitOK, code, stderr)
+
+			data, err := os.ReadFile(fileName)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(data), tt.expected), string(data))
+			assert.Equal(t, "1.3.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+		})
+	}
+}
+
+func TestBumpStdio(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	t.Run("stdin to stdout", func(t *testing.T) {
+		for _, args := range [][]string{{"-l", "minor", "-", "-o", "-"}, {"-l", "minor", "-"}} {
+			code, stdout, stderr := runAppWithStdin(string(data), args...)
+			require.Equal(t, exitOK, code, stderr)
+
+			info, err := model.ParseVersionInfo([]byte(stdout))
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.0", info.StringFileInfo.FileVersion)
+		}
+		assert.Equal(t, "1.2.3", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("file to stdout", func(t *testing.T) {
+		code, stdout, stderr := runApp("bump", fileName, "-o", "-")
+		require.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "\"FileVersion\": \"1.2.4\"")
+		assert.Equal(t, "1.2.3", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("stdin to file", func(t *testing.T) {
+		outputFileName := filepath.Join(t.TempDir(), "versioninfo.json")
+
+		code, stdout, stderr := runAppWithStdin(string(data), "set", "-v", "2.0.0", "-o", outputFileName, "-")
+		require.Equal(t, exitOK, code, stderr)
+		assert.Empty(t, stdout)
+		assert.Equal(t, "2.0.0", readVersionInfoFile(t, outputFileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		code, _, stderr := runAppWithStdin(string(data), "-", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "- can not be used with more than one file")
+
+		code, stdout, _ := runAppWithStdin(string(data), "-output-format", "json", "-")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stdout, `"code": "usage"`)
+
+		code, _, stderr = runAppWithStdin("{", "-")
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, stdinName+":1:")
+	})
+}
+
+func TestBumpBatch(t *testing.T) {
+	newTreeOf := func(t *testing.T, versions map[string]string) string {
+		dir := t.TempDir()
+		for name, version := range versions {
+			fileName := filepath.Join(dir, "cmd", name, "versioninfo.json")
+			require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+			require.NoError(t, os.WriteFile(fileName, []byte(version), 0644))
+		}
+		return dir
+	}
+
+	t.Run("all files", func(t *testing.T) {
+		dir := newTreeOf(t, map[string]string{
+			"a": `{"StringFileInfo": {"FileVersion": "1.2.3", "ProductVersion": "1.2.3"}}`,
+			"b": `{"StringFileInfo": {"FileVersion": "0.9.0", "ProductVersion": "0.9.0"}}`,
+		})
+		a := filepath.Join(dir, "cmd", "a", "versioninfo.json")
+		b := filepath.Join(dir, "cmd", "b", "versioninfo.json")
+
+		code, stdout, stderr := runApp("bump", "-l", "minor", "-syso", "resource.syso", filepath.Join(dir, "cmd")+"/...")
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, a+": 1.2.3 -> 1.3.0\n"+b+": 0.9.0 -> 0.10.0\n", stdout)
+		assert.Equal(t, "1.3.0", readVersionInfoFile(t, a).StringFileInfo.FileVersion)
+		assert.Equal(t, "0.10.0", readVersionInfoFile(t, b).StringFileInfo.FileVersion)
+		assert.FileExists(t, filepath.Join(dir, "cmd", "a", "resource.syso"))
+		assert.FileExists(t, filepath.Join(dir, "cmd", "b", "resource.syso"))
+	})
+
+	t.Run("none written on failure", func(t *testing.T) {
+		dir := newTreeOf(t, map[string]string{
+			"a": `{"StringFileInfo": {"FileVersion": "1.2.3"}}`,
+			"b": `{"StringFileInfo": `,
+		})
+		a := filepath.Join(dir, "cmd", "a", "versioninfo.json")
+		original, err := os.ReadFile(a)
+		require.NoError(t, err)
+
+		code, _, stderr := runApp("bump", filepath.Join(dir, "cmd", "*", "versioninfo.json"))
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, filepath.Join(dir, "cmd", "b", "versioninfo.json")+":")
+		assert.Contains(t, stderr, "1 of 2 files failed, nothing was written")
+
+		data, err := os.ReadFile(a)
+		require.NoError(t, err)
+		assert.Equal(t, string(original), string(data))
+	})
+
+	t.Run("output with many files", func(t *testing.T) {
+		code, _, stderr := runApp("bump", "-o", "out.json", "a.json", "b.json")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "-output can not be used")
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// componentChangeFlag collects -set, -increment and -decrement into the same list, so that they are applied in the given order.
+type componentChangeFlag struct {
+	changes  *[]model.ComponentChange
+	relative bool
+	sign     int
+}
+
+func (f componentChangeFlag) String() string {
+	if f.changes == nil {
+		return ""
+	}
+
+	var result []string
+	for _, change := range *f.changes {
+		result = append(result, fmt.Sprintf("%s=%d", change.Component, change.Value))
+	}
+	return strings.Join(result, ", ")
+}
+
+// Set parses component=value. The value can be omitted for -increment and -decrement, meaning 1.
+func (f componentChangeFlag) Set(value string) error {
+	name, number, found := strings.Cut(value, "=")
+	if !found && !f.relative {
+		return fmt.Errorf("expected component=value but got %q", value)
+	}
+
+	change := model.ComponentChange{Component: model.VersionLevel(name), Value: 1, Relative: f.relative}
+	if found {
+		var err error
+		if change.Value, err = strconv.Atoi(number); err != nil || (f.relative && change.Value < 0) {
+			return fmt.Errorf("expected a non-negative number for %s but got %q", name, number)
+		}
+	}
+	change.Value *= f.sign
+
+	*f.changes = append(*f.changes, change)
+	return nil
+}
+
+// componentChangeFlags defines the flags which change components without resetting the lower ones.
+func (a app) componentChangeFlags(fs *flag.FlagSet) *[]model.ComponentChange {
+	changes := new([]model.ComponentChange)
+	fs.Var(componentChangeFlag{changes: changes, sign: 1}, "set", "component to assign as component=value, e.g. build=1234, can be repeated")
+	fs.Var(componentChangeFlag{changes: changes, relative: true, sign: 1}, "increment", "component to increment as component[=amount] without resetting lower ones, can be repeated")
+	fs.Var(componentChangeFlag{changes: changes, relative: true, sign: -1}, "decrement", "component to decrement as component[=amount] without resetting lower ones, can be repeated")
+	return changes
+}
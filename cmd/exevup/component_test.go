@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentChangeFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	changes := app{}.componentChangeFlags(fs)
+
+	require.NoError(t, fs.Parse([]string{"-set", "build=1234", "-increment", "minor", "-decrement", "patch=2"}))
+	assert.Equal(t, []model.ComponentChange{
+		{Component: model.LevelBuild, Value: 1234},
+		{Component: model.LevelMinor, Value: 1, Relative: true},
+		{Component: model.LevelPatch, Value: -2, Relative: true},
+	}, *changes)
+
+	assert.Error(t, fs.Parse([]string{"-set", "build"}))
+	assert.Error(t, fs.Parse([]string{"-set", "build=x"}))
+	assert.Error(t, fs.Parse([]string{"-increment", "build=-1"}))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func (a app) convert(args []string) error {
+	fs := a.newFlagSet("convert", "exevup convert [flags] -o {output} [file]", "Convert file, which is versioninfo.json by default, to the format of output.\n"+
+		"Formats are chosen by the extension of each file, e.g. .json, .yaml, .yml or .toml, unless -format or -to is given.\n"+
+		"Keys are the same in every format, while comments of file are not kept.")
+
+	outputName := fs.String("output", "", "output file name, - for stdout")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	var to model.Format
+	fs.Var(formatValue{format: &to}, "to", "format of output - "+formatsUsage()+", blank for the extension of output")
+
+	force := fs.Bool("force", false, "overwrite output if it already exists")
+	fs.BoolVar(force, "f", *force, "alias for -force")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	inputFileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	if *outputName == "" {
+		return a.usageError(fs, "-output must be given")
+	}
+	if err = a.checkStdio(fs, []string{inputFileName}, *outputName); err != nil {
+		return err
+	}
+	if *outputName != stdio && !*force && fileExists(*outputName) {
+		return fmt.Errorf("%s already exists, use -force to overwrite", *outputName)
+	}
+
+	//-format은 입력 파일의 형식이므로 출력 파일의 형식은 -to나 확장자로 정함
+	if to == "" {
+		to = model.FormatOf(*outputName)
+	}
+	codec, err := model.CodecOf(to)
+	if err != nil {
+		return err
+	}
+
+	_, info, err := a.readVersionInfo(inputFileName, false)
+	if err != nil {
+		return err
+	}
+
+	data, err := codec.Encode(info)
+	if err != nil {
+		return err
+	}
+
+	if *outputName == stdio {
+		_, err = a.stdout.Write(data)
+	} else {
+		err = writeVersionInfoData(*outputName, data, false)
+	}
+	if err != nil {
+		return err
+	}
+	a.reportFile(fileReport{Input: inputFileName, Output: *outputName, Written: []string{*outputName}})
+	return nil
+}
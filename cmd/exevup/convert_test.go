@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	dir := filepath.Dir(fileName)
+	expected := readVersionInfoFile(t, fileName)
+
+	t.Run("by extension", func(t *testing.T) {
+		yamlFileName := filepath.Join(dir, "versioninfo.yaml")
+		code, _, stderr := runApp("convert", "-o", yamlFileName, fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		data, err := os.ReadFile(yamlFileName)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "StringFileInfo:\n")
+
+		tomlFileName := filepath.Join(dir, "versioninfo.toml")
+		code, _, stderr = runApp("convert", yamlFileName, "-o", tomlFileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		assert.Equal(t, expected, readVersionInfoFile(t, tomlFileName))
+	})
+
+	t.Run("stdio", func(t *testing.T) {
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+
+		code, stdout, stderr := runAppWithStdin(string(data), "convert", "-to", "toml", "-o", "-", "-")
+		require.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "[StringFileInfo]\n")
+
+		code, stdout, stderr = runAppWithStdin(stdout, "convert", "-format", "toml", "-o", "-", "-")
+		require.Equal(t, exitOK, code, stderr)
+		info, err := model.ParseVersionInfo([]byte(stdout))
+		require.NoError(t, err)
+		assert.Equal(t, expected, info)
+	})
+
+	t.Run("existing output", func(t *testing.T) {
+		output := newVersionInfoFile(t, "2.0.0")
+
+		code, _, stderr := runApp("convert", "-o", output, fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "already exists")
+
+		code, _, stderr = runApp("convert", "-f", "-o", output, fileName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.2.3", readVersionInfoFile(t, output).StringFileInfo.FileVersion)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		code, _, _ := runApp("convert", fileName)
+		assert.Equal(t, exitUsage, code)
+
+		code, _, stderr := runApp("convert", "-to", "xml", "-o", "-", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "unknown version info format")
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// yearOf returns the year given by -year, or the year of now.
+func yearOf(value string) (int, error) {
+	if value != "" {
+		return strconv.Atoi(value)
+	}
+
+	date, err := now()
+	if err != nil {
+		return 0, err
+	}
+	return date.Year(), nil
+}
+
+func copyrightChangeOf(before model.Info, after model.Info) string {
+	return fmt.Sprintf("%q -> %q", before.StringFileInfo.LegalCopyright, after.StringFileInfo.LegalCopyright)
+}
+
+// prepareCopyright returns the content of every file with the year of LegalCopyright extended to year.
+func (a app) prepareCopyright(fileNames []string, year int, reformat bool) ([]*bumpResult, []error) {
+	return forEachFile(fileNames, func(fileName string) (result *bumpResult, err error) {
+		result = &bumpResult{inputFileName: fileName, outputFileName: fileName}
+		if result.original, result.before, err = a.readVersionInfo(fileName, false); err != nil {
+			return
+		}
+
+		var found bool
+		if result.after, found = result.before.CopyrightYearUpdated(year); !found {
+			fmt.Fprintf(&result.log, "no year in LegalCopyright %q\n", result.before.StringFileInfo.LegalCopyright)
+		}
+
+		result.data, err = a.versionInfoDataOf(fileName, fileName, result.original, result.after, reformat)
+		return
+	})
+}
+
+func (a app) copyright(args []string) error {
+	fs := a.newFlagSet("copyright", "exevup copyright [flags] [file...]", "Extend the year in StringFileInfo.LegalCopyright of files, which is versioninfo.json by default,\n"+
+		"to the current year, e.g. 2019-2024 to 2019-2025. The year is taken from SOURCE_DATE_EPOCH when it is set.\n"+
+		"Files can be globs or dir/... as for bump. With -check, exit code is 3 when any copyright is stale.")
+
+	yearValue := fs.String("year", "", "year to extend to, blank for the current year")
+	check := fs.Bool("check", false, "report stale copyrights instead of writing them")
+	reformat := fs.Bool("reformat", false, "rewrite the whole file instead of only the changed values")
+	backup := fs.Bool("backup", false, "keep the previous content of each file as .bak")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a unified diff instead of writing any file")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fileNames, err := expandFileNames(positional)
+	if err != nil {
+		return err
+	}
+
+	if err = a.checkStdio(fs, fileNames, ""); err != nil {
+		return err
+	}
+
+	year, err := yearOf(*yearValue)
+	if err != nil && *yearValue != "" {
+		return a.usageError(fs, "invalid -year %q", *yearValue)
+	}
+	if err != nil {
+		return err
+	}
+
+	results, errs := a.prepareCopyright(fileNames, year, *reformat)
+
+	//바뀌지 않는 파일은 다시 쓰지 않지만, 표준 입력은 파이프라인이 끊기지 않도록 그대로 출력함
+	var changed []*bumpResult
+	var changedErrs []error
+	for i, result := range results {
+		if errs[i] != nil || !bytes.Equal(result.original, result.data) || (!*check && result.outputFileName == stdio) {
+			changed = append(changed, result)
+			changedErrs = append(changedErrs, errs[i])
+		} else if result.log.Len() > 0 {
+			fmt.Fprintf(a.stderr, "%s: %s", result.inputFileName, result.log.String())
+			a.reportFile(result.report(nil))
+		}
+	}
+
+	if !*check {
+		return a.commitResults(changed, changedErrs, nil, commitOptions{batch: true, backup: *backup, dryRun: *dryRun, changeOf: copyrightChangeOf})
+	}
+
+	for i, result := range changed {
+		if changedErrs[i] != nil {
+			return fmt.Errorf("%s: %w", result.inputFileName, changedErrs[i])
+		}
+		a.reportFile(result.report(nil))
+		fmt.Fprintf(a.stdout, "%s: stale, %s\n", result.inputFileName, copyrightChangeOf(result.before, result.after))
+	}
+	if len(changed) > 0 {
+		return exitError{code: exitInvalid, err: fmt.Errorf("%d of %d files have a stale copyright", len(changed), len(results))}
+	}
+	return nil
+}
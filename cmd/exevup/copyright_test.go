@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYearOf(t *testing.T) {
+	year, err := yearOf("2030")
+	require.NoError(t, err)
+	assert.Equal(t, 2030, year)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1735689600")
+	year, err = yearOf("")
+	require.NoError(t, err)
+	assert.Equal(t, 2025, year)
+
+	_, err = yearOf("twenty")
+	assert.Error(t, err)
+}
+
+func TestCopyright(t *testing.T) {
+	newCopyrightFile := func(t *testing.T, copyright string) string {
+		fileName := newVersionInfoFile(t, "1.0.0")
+		code, _, stderr := runApp("set", "-copyright", copyright, fileName)
+		require.Equal(t, exitOK, code, stderr)
+		return fileName
+	}
+
+	t.Run("update", func(t *testing.T) {
+		stale := newCopyrightFile(t, "Copyright (c) 2019-2024 Example Corp")
+		current := newCopyrightFile(t, "Copyright (c) 2025 Example Corp")
+		t.Setenv("SOURCE_DATE_EPOCH", "1735689600")
+
+		code, stdout, stderr := runApp("copyright", stale, current)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, stale+": \"Copyright (c) 2019-2024 Example Corp\" -> \"Copyright (c) 2019-2025 Example Corp\"\n", stdout)
+		assert.Equal(t, "Copyright (c) 2019-2025 Example Corp", readVersionInfoFile(t, stale).StringFileInfo.LegalCopyright)
+		assert.Equal(t, "Copyright (c) 2025 Example Corp", readVersionInfoFile(t, current).StringFileInfo.LegalCopyright)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		stale := newCopyrightFile(t, "Copyright 2019")
+
+		code, stdout, _ := runApp("copyright", "-check", "-year", "2025", stale)
+		assert.Equal(t, exitInvalid, code)
+		assert.Contains(t, stdout, stale+": stale")
+		assert.Equal(t, "Copyright 2019", readVersionInfoFile(t, stale).StringFileInfo.LegalCopyright)
+
+		code, _, stderr := runApp("copyright", "-check", "-year", "2019", stale)
+		assert.Equal(t, exitOK, code, stderr)
+	})
+
+	t.Run("invalid year", func(t *testing.T) {
+		fileName := newCopyrightFile(t, "Copyright 2019")
+
+		code, _, stderr := runApp("copyright", "-year", "twenty", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, `invalid -year "twenty"`)
+
+		t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+		code, _, stderr = runApp("copyright", fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, `invalid SOURCE_DATE_EPOCH "yesterday"`)
+		assert.NotContains(t, stderr, "-year")
+	})
+
+	t.Run("no year", func(t *testing.T) {
+		fileName := newCopyrightFile(t, "Example Corp")
+
+		code, _, stderr := runApp("copyright", "-year", "2025", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stderr, "no year in LegalCopyright")
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		for copyright, expected := range map[string]string{
+			"Copyright 2019": "Copyright 2019-2025",
+			"Copyright 2025": "Copyright 2025",
+		} {
+			data, err := os.ReadFile(newCopyrightFile(t, copyright))
+			require.NoError(t, err)
+
+			code, stdout, stderr := runAppWithStdin(string(data), "copyright", "-year", "2025", "-")
+			require.Equal(t, exitOK, code, stderr)
+			info, err := model.ParseVersionInfo([]byte(stdout))
+			require.NoError(t, err)
+			assert.Equal(t, expected, info.StringFileInfo.LegalCopyright)
+		}
+	})
+
+	t.Run("invalid year", func(t *testing.T) {
+		code, _, _ := runApp("copyright", "-year", "next", newCopyrightFile(t, "Copyright 2019"))
+		assert.Equal(t, exitUsage, code)
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// splitLines splits data into lines which keep their line feed, so that a missing one at the end can be told.
+func splitLines(data []byte) (lines []string) {
+	text := string(data)
+	for text != "" {
+		end := strings.IndexByte(text, '\n') + 1
+		if end == 0 {
+			end = len(text)
+		}
+		lines = append(lines, text[:end])
+		text = text[end:]
+	}
+	return
+}
+
+// diffOps returns the edit script from a to b by the longest common subsequence of lines.
+func diffOps(a []string, b []string) (ops []diffOp) {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lengths[i+1][j] >= lengths[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j]})
+			j++
+		}
+	}
+	return
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// unifiedDiff returns the changes from a to b in unified format, or an empty string when they are the same.
+func unifiedDiff(aName string, bName string, a []byte, b []byte) string {
+	ops := diffOps(splitLines(a), splitLines(b))
+
+	//aLines[k], bLines[k]는 k번째 op 앞에 있는 각 파일의 줄 수
+	aLines, bLines := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	for k, op := range ops {
+		aLines[k+1], bLines[k+1] = aLines[k], bLines[k]
+		if op.kind != '+' {
+			aLines[k+1]++
+		}
+		if op.kind != '-' {
+			bLines[k+1]++
+		}
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		//문맥이 겹치는 변경은 하나의 hunk로 묶음
+		start, end := max(i-diffContext, 0), i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end = min(end+diffContext, len(ops))
+
+		if builder.Len() == 0 {
+			fmt.Fprintf(&builder, "--- %s\n+++ %s\n", aName, bName)
+		}
+		fmt.Fprintf(&builder, "@@ -%s +%s @@\n",
+			hunkRange(aLines[start], aLines[end]-aLines[start]),
+			hunkRange(bLines[start], bLines[end]-bLines[start]))
+		for _, op := range ops[start:end] {
+			builder.WriteByte(op.kind)
+			builder.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				builder.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return builder.String()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected string
+	}{
+		{
+			name: "same",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+		},
+		{
+			name:     "changed line",
+			a:        "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			b:        "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:     "separate hunks",
+			a:        "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:        "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name:     "insertion into empty",
+			a:        "",
+			b:        "{}",
+			expected: "--- a\n+++ b\n@@ -0,0 +1 @@\n+{}\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, unifiedDiff("a", "b", []byte(tt.a), []byte(tt.b)))
+		})
+	}
+}
+
+func TestUnifiedDiffAppliesWithPatch(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("patch is not installed")
+	}
+
+	a := strings.Repeat("line\n", 20) + "end"
+	b := "first\n" + strings.Repeat("line\n", 10) + "middle\n" + strings.Repeat("line\n", 10) + "end\n"
+
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(fileName, []byte(a), 0644))
+
+	cmd := exec.Command("patch", "-s", fileName)
+	cmd.Stdin = strings.NewReader(unifiedDiff("a/file", "b/file", []byte(a), []byte(b)))
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, b, string(data))
+}
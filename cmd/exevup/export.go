@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// resourceFormatRC is the resource script of rc and windres, which export and import exchange version info with.
+const resourceFormatRC = "rc"
+
+func (a app) checkResourceFormat(fs *flag.FlagSet, format string) error {
+	if format != resourceFormatRC {
+		return a.usageError(fs, "unknown resource format %q, expected %s", format, resourceFormatRC)
+	}
+	return nil
+}
+
+func (a app) export(args []string) error {
+	fs := a.newFlagSet("export", "exevup export [flags] [file]", "Export file, which is versioninfo.json by default, as a VERSIONINFO resource script for rc and windres.\n"+
+		"Empty StringFileInfo values are left out, and IconPath and ManifestPath are not exported.")
+
+	format := fs.String("format", resourceFormatRC, "format of output - "+resourceFormatRC)
+
+	outputName := fs.String("output", stdio, "output file name, - for stdout")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	force := fs.Bool("force", false, "overwrite output if it already exists")
+	fs.BoolVar(force, "f", *force, "alias for -force")
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	inputFileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+	if err = a.checkResourceFormat(fs, *format); err != nil {
+		return err
+	}
+	if err = a.checkStdio(fs, []string{inputFileName}, *outputName); err != nil {
+		return err
+	}
+	if *outputName != stdio && !*force && fileExists(*outputName) {
+		return fmt.Errorf("%s already exists, use -force to overwrite", *outputName)
+	}
+
+	_, info, err := a.readVersionInfo(inputFileName, false)
+	if err != nil {
+		return err
+	}
+
+	data := model.StringifyResourceScript(info)
+	if *outputName == stdio {
+		_, err = a.stdout.Write(data)
+	} else {
+		err = writeVersionInfoData(*outputName, data, false)
+	}
+	if err != nil {
+		return err
+	}
+	a.reportFile(fileReport{Input: inputFileName, Output: *outputName, Written: []string{*outputName}})
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+
+	t.Run("stdout", func(t *testing.T) {
+		code, stdout, stderr := runApp("export", "-format", "rc", fileName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "1 VERSIONINFO\nFILEVERSION 1,2,3,0\n")
+		assert.Contains(t, stdout, `VALUE "FileVersion", L"1.2.3"`)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "app.rc")
+		code, _, stderr := runApp("export", "-o", output, fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "BLOCK \"StringFileInfo\"")
+
+		code, _, stderr = runApp("export", "-o", output, fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "already exists")
+
+		code, _, stderr = runApp("export", "-f", "-o", output, fileName)
+		assert.Equal(t, exitOK, code, stderr)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		code, _, stderr := runApp("export", "-format", "res", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "unknown resource format")
+
+		code, _, _ = runApp("export", "-output-format", "json", fileName)
+		assert.Equal(t, exitUsage, code)
+	})
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// stringFieldFlagNames follows the flag names of goversioninfo.
+var stringFieldFlagNames = []struct {
+	name  string
+	field model.StringField
+}{
+	{name: "comment", field: model.FieldComments},
+	{name: "company", field: model.FieldCompanyName},
+	{name: "description", field: model.FieldFileDescription},
+	{name: "internal-name", field: model.FieldInternalName},
+	{name: "copyright", field: model.FieldLegalCopyright},
+	{name: "trademark", field: model.FieldLegalTrademarks},
+	{name: "original-name", field: model.FieldOriginalFilename},
+	{name: "private-build", field: model.FieldPrivateBuild},
+	{name: "product-name", field: model.FieldProductName},
+	{name: "special-build", field: model.FieldSpecialBuild},
+}
+
+// assignments collects repeated Key=Value flags such as -field.
+type assignments []string
+
+func (f *assignments) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *assignments) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("expected Key=Value but got %q", value)
+	}
+	*f = append(*f, value)
+	return nil
+}
+
+type stringFieldFlags struct {
+	values      map[string]*string
+	assignments assignments
+}
+
+func (a app) stringFieldFlags(fs *flag.FlagSet) *stringFieldFlags {
+	flags := &stringFieldFlags{values: make(map[string]*string)}
+	for _, flagName := range stringFieldFlagNames {
+		flags.values[flagName.name] = fs.String(flagName.name, "", fmt.Sprintf("StringFileInfo.%s", flagName.field))
+	}
+	fs.Var(&flags.assignments, "field", "StringFileInfo field to set as Key=Value, can be repeated")
+	return flags
+}
+
+// updated returns info with the fields given on the command line, leaving fields whose flag was not given untouched.
+func (f *stringFieldFlags) updated(fs *flag.FlagSet, info model.Info) (model.Info, error) {
+	var err error
+	for _, flagName := range stringFieldFlagNames {
+		if isFlagSet(fs, flagName.name) {
+			if info, err = info.StringFieldUpdated(flagName.field, *f.values[flagName.name]); err != nil {
+				return info, err
+			}
+		}
+	}
+
+	for _, assignment := range f.assignments {
+		key, value, _ := strings.Cut(assignment, "=")
+		field, err := model.ParseStringField(key)
+		if err != nil {
+			return info, err
+		}
+		if info, err = info.StringFieldUpdated(field, value); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+func (f *stringFieldFlags) isSet(fs *flag.FlagSet) bool {
+	for _, flagName := range stringFieldFlagNames {
+		if isFlagSet(fs, flagName.name) {
+			return true
+		}
+	}
+	return len(f.assignments) > 0
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) (result bool) {
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			result = true
+		}
+	})
+	return
+}
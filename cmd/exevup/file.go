@@ -0,0 +1,245 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/atomicfile"
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func fileExists(fileName string) bool {
+	_, err := os.Stat(fileName)
+	return err == nil
+}
+
+// stdio is the file name which stands for stdin as input and for stdout as output.
+const stdio = "-"
+
+// stdinName is the name of stdin in errors.
+const stdinName = "<stdin>"
+
+func readVersionInfoData(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.NotFoundError{FileName: fileName}
+	}
+	return data, err
+}
+
+// readInput reads fileName, or stdin when it is -.
+func (a app) readInput(fileName string) ([]byte, error) {
+	if fileName != stdio {
+		return readVersionInfoData(fileName)
+	}
+
+	data, err := io.ReadAll(a.stdin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", stdinName, err)
+	}
+	return data, nil
+}
+
+// formatValue is a flag choosing a format of version info files.
+type formatValue struct {
+	format *model.Format
+}
+
+func (v formatValue) String() string {
+	if v.format == nil {
+		return ""
+	}
+	return string(*v.format)
+}
+
+func (v formatValue) Set(value string) error {
+	if _, err := model.CodecOf(model.Format(value)); err != nil {
+		return err
+	}
+	*v.format = model.Format(value)
+	return nil
+}
+
+func formatsUsage() string {
+	formats := make([]string, 0, len(model.Formats()))
+	for _, format := range model.Formats() {
+		formats = append(formats, string(format))
+	}
+	return strings.Join(formats, "/")
+}
+
+func (a app) formatFlag(fs *flag.FlagSet) {
+	if a.format != nil {
+		fs.Var(formatValue{format: a.format}, "format", "format of version info files - "+formatsUsage()+", blank for the extension of each file")
+	}
+}
+
+// formatOf returns the format given by -format, or the format of fileName by its extension.
+func (a app) formatOf(fileName string) model.Format {
+	if a.format != nil && *a.format != "" {
+		return *a.format
+	}
+	return model.FormatOf(fileName)
+}
+
+func parseVersionInfoData(fileName string, format model.Format, data []byte) (model.Info, error) {
+	codec, err := model.CodecOf(format)
+	if err != nil {
+		return model.Info{}, err
+	}
+	info, err := codec.Decode(data)
+
+	var parseErr model.ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.FileName = fileName
+		return info, parseErr
+	}
+	return info, err
+}
+
+// parseVersionInfo reads the version info in format from r, which is named fileName in errors.
+func parseVersionInfo(r io.Reader, fileName string, format model.Format) (model.Info, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return model.Info{}, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return parseVersionInfoData(fileName, format, data)
+}
+
+// parseVersionInfoFromInput parses fileName, or stdin when it is -, in the format of a.formatOf.
+func (a app) parseVersionInfoFromInput(fileName string) (model.Info, error) {
+	if fileName == stdio {
+		return parseVersionInfo(a.stdin, stdinName, a.formatOf(fileName))
+	}
+
+	file, err := os.Open(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return model.Info{}, model.NotFoundError{FileName: fileName}
+	}
+	if err != nil {
+		return model.Info{}, err
+	}
+	defer file.Close()
+
+	return parseVersionInfo(file, fileName, a.formatOf(fileName))
+}
+
+// readVersionInfo returns the content of fileName, or stdin when it is -, and the info parsed from it.
+// With create, a missing file is read as model.DefaultInfo with no content, so that it is written in full.
+func (a app) readVersionInfo(fileName string, create bool) ([]byte, model.Info, error) {
+	original, err := a.readInput(fileName)
+	if create && errors.Is(err, fs.ErrNotExist) {
+		return nil, model.DefaultInfo(), nil
+	}
+	if err != nil {
+		return nil, model.Info{}, err
+	}
+
+	format := a.formatOf(fileName)
+	if fileName == stdio {
+		fileName = stdinName
+	}
+	info, err := parseVersionInfoData(fileName, format, original)
+	return original, info, err
+}
+
+// writeVersionInfoData replaces fileName atomically, so that it is never left empty or partially written.
+func writeVersionInfoData(fileName string, data []byte, backup bool) error {
+	return atomicfile.WriteFile(fileName, data, atomicfile.Options{Backup: backup})
+}
+
+type pendingWrite struct {
+	fileName string
+	data     []byte
+	noBackup bool //생성된 파일이라 -backup이어도 백업하지 않음
+}
+
+// writeVersionInfoFiles writes every file to a temporary file first and renames them only when all are written,
+// so that a failure leaves every file untouched.
+func writeVersionInfoFiles(writes []pendingWrite, backup bool) error {
+	files := make([]*atomicfile.File, 0, len(writes))
+	defer func() {
+		for _, file := range files {
+			file.Abort()
+		}
+	}()
+
+	for _, write := range writes {
+		file, err := atomicfile.Create(write.fileName, atomicfile.Options{Backup: backup && !write.noBackup})
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+
+		if _, err = file.Write(write.data); err != nil {
+			return fmt.Errorf("%s: %w", write.fileName, err)
+		}
+	}
+
+	return commitFiles(files)
+}
+
+// commitFiles renames every file over its target. When one fails, the targets renamed before it are restored
+// to their previous content, or removed when they did not exist.
+func commitFiles(files []*atomicfile.File) error {
+	previous := make([][]byte, len(files))
+	existed := make([]bool, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file.Name())
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		previous[i], existed[i] = data, err == nil
+	}
+
+	for i, file := range files {
+		if err := file.Commit(); err != nil {
+			err = fmt.Errorf("%s: %w", file.Name(), err)
+			for j, committed := range files[:i] {
+				if existed[j] {
+					err = errors.Join(err, atomicfile.WriteFile(committed.Name(), previous[j], atomicfile.Options{}))
+				} else {
+					err = errors.Join(err, os.Remove(committed.Name()))
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStdio rejects - among more than one file, and writing to stdout with -output-format json,
+// which prints the report to stdout. Output is the -output flag, blank for each input itself.
+func (a app) checkStdio(fs *flag.FlagSet, fileNames []string, output string) error {
+	if len(fileNames) > 1 && slices.Contains(fileNames, stdio) {
+		return a.usageError(fs, "%s can not be used with more than one file", stdio)
+	}
+
+	toStdout := output == stdio || (output == "" && slices.Contains(fileNames, stdio))
+	if toStdout && a.out != nil && a.out.format == outputFormatJSON {
+		return a.usageError(fs, "-output-format json can not be used when writing to stdout")
+	}
+	return nil
+}
+
+// versionInfoDataOf returns the data of outputFileName for info, which is original of inputFileName with only the changed values
+// rewritten unless reformat is set or the files have different formats.
+func (a app) versionInfoDataOf(inputFileName string, outputFileName string, original []byte, info model.Info, reformat bool) ([]byte, error) {
+	format := a.formatOf(outputFileName)
+	codec, err := model.CodecOf(format)
+	if err != nil {
+		return nil, err
+	}
+
+	if reformat || format != a.formatOf(inputFileName) {
+		return codec.Encode(info)
+	}
+	return codec.Patch(original, info)
+}
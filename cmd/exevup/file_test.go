@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/atomicfile"
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadVersionInfo(t *testing.T) {
+	// Create a temporary test file
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test_versioninfo.json")
+
+	validContent := `{
+		"FixedFileInfo": {
+			"FileVersion": {
+				"Major": 1,
+				"Minor": 2,
+				"Patch": 3,
+				"Build": 4
+			},
+			"ProductVersion": {
+				"Major": 1,
+				"Minor": 2,
+				"Patch": 3,
+				"Build": 4
+			}
+		},
+		"StringFileInfo": {
+			"FileVersion": "1.2.3.4",
+			"ProductVersion": "1.2.3.4"
+		}
+	}`
+
+	t.Run("valid file", func(t *testing.T) {
+		err := os.WriteFile(testFile, []byte(validContent), 0644)
+		require.NoError(t, err)
+
+		original, info, err := app{}.readVersionInfo(testFile, false)
+		require.NoError(t, err)
+		assert.Equal(t, validContent, string(original))
+		assert.Equal(t, 1, info.FixedFileInfo.FileVersion.Major)
+		assert.Equal(t, "1.2.3.4", info.StringFileInfo.FileVersion)
+	})
+
+	t.Run("non-existent file", func(t *testing.T) {
+		nonExistentFile := filepath.Join(tempDir, "non_existent.json")
+
+		_, _, err := app{}.readVersionInfo(nonExistentFile, false)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.EqualError(t, err, nonExistentFile+": file not found")
+		assert.NoFileExists(t, nonExistentFile)
+	})
+
+	t.Run("invalid JSON file", func(t *testing.T) {
+		invalidFile := filepath.Join(tempDir, "invalid.json")
+		err := os.WriteFile(invalidFile, []byte("{invalid json"), 0644)
+		require.NoError(t, err)
+
+		_, _, err = app{}.readVersionInfo(invalidFile, false)
+		var parseErr model.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, invalidFile, parseErr.FileName)
+		assert.Equal(t, 1, parseErr.Line)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		fileName := filepath.Join(tempDir, "versioninfo.json")
+
+		original, info, err := app{}.readVersionInfo(fileName, true)
+		require.NoError(t, err)
+		assert.Nil(t, original)
+		assert.Equal(t, model.DefaultInfo(), info)
+		assert.NoFileExists(t, fileName)
+	})
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	info, err := versionSet(model.Info{}, "1.2.3", model.TargetBoth, "")
+	require.NoError(t, err)
+
+	for _, format := range model.Formats() {
+		codec, err := model.CodecOf(format)
+		require.NoError(t, err)
+		data, err := codec.Encode(info)
+		require.NoError(t, err)
+
+		parsed, err := parseVersionInfo(bytes.NewReader(data), stdinName, format)
+		require.NoError(t, err, format)
+		assert.Equal(t, info, parsed, format)
+	}
+
+	_, err = parseVersionInfo(strings.NewReader("{invalid json"), stdinName, model.FormatJSON)
+	var parseErr model.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, stdinName, parseErr.FileName)
+
+	_, err = parseVersionInfo(strings.NewReader(""), stdinName, "xml")
+	assert.ErrorIs(t, err, model.ErrUnknownFormat)
+}
+
+func TestWriteVersionInfoFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "output_test.json")
+
+	// Create a minimal valid info object
+	data, err := model.StringifyVersionInfo(model.Info{})
+	require.NoError(t, err)
+	writes := []pendingWrite{{fileName: testFile, data: data}}
+
+	t.Run("write to new file", func(t *testing.T) {
+		err := writeVersionInfoFiles(writes, false)
+		assert.NoError(t, err)
+
+		// Verify file was created
+		_, err = os.Stat(testFile)
+		assert.NoError(t, err)
+	})
+
+	t.Run("overwrite existing file", func(t *testing.T) {
+		// Write some content first
+		err := os.WriteFile(testFile, []byte("existing content"), 0644)
+		require.NoError(t, err)
+
+		err = writeVersionInfoFiles(writes, false)
+		assert.NoError(t, err)
+
+		// Read back and verify it was overwritten
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "existing content")
+	})
+
+	t.Run("failure leaves every file untouched", func(t *testing.T) {
+		err := os.WriteFile(testFile, []byte("existing content"), 0644)
+		require.NoError(t, err)
+
+		err = writeVersionInfoFiles(append(writes, pendingWrite{fileName: filepath.Join(tempDir, "missing", "versioninfo.json")}), false)
+		assert.Error(t, err)
+
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		assert.Equal(t, "existing content", string(content))
+	})
+}
+
+func TestCommitFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files can not be removed")
+	}
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.json")
+	created := filepath.Join(dir, "created.json")
+	failing := filepath.Join(dir, "failing.json")
+	require.NoError(t, os.WriteFile(existing, []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(failing, []byte("old"), 0644))
+
+	var files []*atomicfile.File
+	for _, fileName := range []string{existing, created, failing} {
+		file, err := atomicfile.Create(fileName, atomicfile.Options{})
+		require.NoError(t, err)
+		defer file.Abort()
+		_, err = file.Write([]byte("new"))
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	//마지막 파일의 임시 파일을 지워서 이름 바꾸기가 실패하게 함
+	temps, err := filepath.Glob(filepath.Join(dir, ".failing.json.*.tmp"))
+	require.NoError(t, err)
+	require.Len(t, temps, 1)
+	require.NoError(t, os.Remove(temps[0]))
+
+	assert.Error(t, commitFiles(files))
+	for _, fileName := range []string{existing, failing} {
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(data), fileName)
+	}
+	assert.NoFileExists(t, created)
+}
+
+func TestVersionInfoDataOf(t *testing.T) {
+	original := []byte("{\"StringFileInfo\": {\"FileVersion\": \"1.0\"}}\n")
+
+	info, err := model.ParseVersionInfo(original)
+	require.NoError(t, err)
+	info.StringFileInfo.FileVersion = "1.1"
+
+	data, err := app{}.versionInfoDataOf("a.json", "b.json", original, info, false)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"StringFileInfo\": {\"FileVersion\": \"1.1\"}}\n", string(data))
+
+	data, err = app{}.versionInfoDataOf("a.json", "b.json", original, info, true)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "\t\"FixedFileInfo\"")
+
+	data, err = app{}.versionInfoDataOf("a.json", "b.yaml", original, info, false)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "StringFileInfo:\n")
+
+	format := model.FormatTOML
+	data, err = app{format: &format}.versionInfoDataOf("a.json", "b.json", original, info, true)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[StringFileInfo]\n")
+}
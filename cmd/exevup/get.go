@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func versionStringOf(versionString string, version model.Version, fixed bool) string {
+	if fixed || versionString == "" {
+		return version.String(model.NotationDetail)
+	}
+	return versionString
+}
+
+// versionMatches reports whether actual is expected, regardless of the notation when both are plain versions,
+// so that 1.2.3 matches 1.2.3.0.
+func versionMatches(actual string, expected string) bool {
+	if actual == expected {
+		return true
+	}
+
+	actualVersion, err := model.ParseVersion(actual)
+	if err != nil {
+		return false
+	}
+	expectedVersion, err := model.ParseVersion(expected)
+	return err == nil && actualVersion == expectedVersion
+}
+
+func checkVersion(name string, actual string, expected string) error {
+	if !versionMatches(actual, expected) {
+		return exitError{code: exitInvalid, err: fmt.Errorf("%s version is %s, expected %s", name, actual, expected)}
+	}
+	return nil
+}
+
+func (a app) get(args []string) error {
+	fs := a.newFlagSet("get", "exevup get [flags] [file]", "Print the file and product version of file, which is versioninfo.json by default.\nWith -check, exit code is 3 when the version is not the expected one.")
+
+	targetValue := fs.String("target", string(model.TargetBoth), "target to print - both/file/product")
+	fs.StringVar(targetValue, "t", *targetValue, "alias for -target")
+
+	fixed := fs.Bool("fixed", false, "print the version of FixedFileInfo instead of StringFileInfo")
+
+	check := fs.String("check", "", "expected version, which the version of target must match instead of being printed")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	target, err := a.targetOf(fs, *targetValue)
+	if err != nil {
+		return err
+	}
+
+	info, err := a.parseVersionInfoFromInput(fileName)
+	if err != nil {
+		return err
+	}
+
+	fileVersion, err := info.GetFileVersion()
+	if err != nil {
+		return err
+	}
+
+	productVersion, err := info.GetProductVersion()
+	if err != nil {
+		return err
+	}
+
+	fileString := versionStringOf(info.StringFileInfo.FileVersion, fileVersion, *fixed)
+	productString := versionStringOf(info.StringFileInfo.ProductVersion, productVersion, *fixed)
+	a.reportOptions(target, "", false)
+	a.reportFile(fileReport{Input: fileName, Version: &versionReport{File: fileString, Product: productString}})
+
+	if *check != "" {
+		if target != model.TargetProduct {
+			if err = checkVersion("file", fileString, *check); err != nil {
+				return err
+			}
+		}
+		if target != model.TargetFile {
+			return checkVersion("product", productString, *check)
+		}
+		return nil
+	}
+
+	switch target {
+	case model.TargetFile:
+		fmt.Fprintln(a.stdout, fileString)
+	case model.TargetProduct:
+		fmt.Fprintln(a.stdout, productString)
+	case model.TargetBoth:
+		fmt.Fprintf(a.stdout, "file: %s\nproduct: %s\n", fileString, productString)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionStringOf(t *testing.T) {
+	version := model.Version{Major: 1, Minor: 4}
+
+	assert.Equal(t, "1.4.0-rc.1", versionStringOf("1.4.0-rc.1", version, false))
+	assert.Equal(t, "1.4.0.0", versionStringOf("1.4.0-rc.1", version, true))
+	assert.Equal(t, "1.4.0.0", versionStringOf("", version, false))
+}
+
+func TestGet(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.4.0-rc.2")
+	info := readVersionInfoFile(t, fileName)
+	info, err := versionSet(info, "1.3.0", model.TargetProduct, "")
+	require.NoError(t, err)
+	writeVersionInfoFile(t, fileName, info)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "both",
+			args:     []string{"get", fileName},
+			expected: "file: 1.4.0-rc.2\nproduct: 1.3.0\n",
+		},
+		{
+			name:     "file",
+			args:     []string{"get", "-t", "file", fileName},
+			expected: "1.4.0-rc.2\n",
+		},
+		{
+			name:     "fixed product",
+			args:     []string{"get", fileName, "-target", "product", "-fixed"},
+			expected: "1.3.0.65535\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runApp(tt.args...)
+			assert.Equal(t, exitOK, code, stderr)
+			assert.Equal(t, tt.expected, stdout)
+		})
+	}
+}
+
+func TestGetStdin(t *testing.T) {
+	data, err := os.ReadFile(newVersionInfoFile(t, "1.4.0"))
+	require.NoError(t, err)
+
+	code, stdout, stderr := runAppWithStdin(string(data), "get", "-t", "file", "-")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "1.4.0\n", stdout)
+
+	code, _, stderr = runAppWithStdin("{", "get", "-")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, stdinName+":1:")
+}
+
+func TestVersionMatches(t *testing.T) {
+	assert.True(t, versionMatches("1.2.3", "1.2.3"))
+	assert.True(t, versionMatches("1.2.3", "1.2.3.0"))
+	assert.True(t, versionMatches("1.4.0-rc.1", "1.4.0-rc.1"))
+	assert.False(t, versionMatches("1.4.0-rc.1", "1.4.0"))
+	assert.False(t, versionMatches("1.2.3", "1.2.4"))
+}
+
+func TestGetCheck(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	info, err := versionSet(readVersionInfoFile(t, fileName), "1.3.0", model.TargetProduct, "")
+	require.NoError(t, err)
+	writeVersionInfoFile(t, fileName, info)
+
+	code, stdout, stderr := runApp("get", "-check", "1.2.3", "-t", "file", fileName)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Empty(t, stdout)
+
+	code, _, stderr = runApp("get", "-check", "1.2.3.0", "-fixed", "-t", "file", fileName)
+	assert.Equal(t, exitOK, code, stderr)
+
+	code, _, stderr = runApp("get", "-check", "1.2.3", fileName)
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, stderr, "product version is 1.3.0, expected 1.2.3")
+}
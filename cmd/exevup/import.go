@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func (a app) importScript(args []string) error {
+	fs := a.newFlagSet("import", "exevup import [flags] {file}", "Import the first VERSIONINFO resource of file, which is a resource script of rc or windres, into output.\n"+
+		"The format of output is chosen by its extension, e.g. .json, .yaml, .yml or .toml, unless -to is given.\n"+
+		"Values which goversioninfo has no field for are reported and left out.")
+
+	format := fs.String("format", resourceFormatRC, "format of file - "+resourceFormatRC)
+
+	outputName := fs.String("output", defaultFileName, "output file name, - for stdout")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	var to model.Format
+	fs.Var(formatValue{format: &to}, "to", "format of output - "+formatsUsage()+", blank for the extension of output")
+
+	force := fs.Bool("force", false, "overwrite output if it already exists")
+	fs.BoolVar(force, "f", *force, "alias for -force")
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		return a.usageError(fs, "file is required")
+	}
+	inputFileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+	if err = a.checkResourceFormat(fs, *format); err != nil {
+		return err
+	}
+	if err = a.checkStdio(fs, []string{inputFileName}, *outputName); err != nil {
+		return err
+	}
+	if *outputName != stdio && !*force && fileExists(*outputName) {
+		return fmt.Errorf("%s already exists, use -force to overwrite", *outputName)
+	}
+
+	if to == "" {
+		to = model.FormatOf(*outputName)
+	}
+	codec, err := model.CodecOf(to)
+	if err != nil {
+		return err
+	}
+
+	script, err := a.readInput(inputFileName)
+	if err != nil {
+		return err
+	}
+
+	name := inputFileName
+	if name == stdio {
+		name = stdinName
+	}
+	info, ignored, err := model.ParseResourceScript(script)
+	var parseErr model.ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.FileName = name
+		return parseErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	data, err := codec.Encode(info)
+	if err != nil {
+		return err
+	}
+
+	if *outputName == stdio {
+		_, err = a.stdout.Write(data)
+	} else {
+		err = writeVersionInfoData(*outputName, data, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	var warnings []string
+	for _, value := range ignored {
+		warnings = append(warnings, fmt.Sprintf("%s is not kept", value))
+		fmt.Fprintf(a.stderr, "%s: %s is not kept\n", name, value)
+	}
+	a.reportFile(fileReport{Input: inputFileName, Output: *outputName, Written: []string{*outputName}, Warnings: warnings})
+	return nil
+}
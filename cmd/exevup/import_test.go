@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResourceScript = `#include "winver.h"
+
+VS_VERSION_INFO VERSIONINFO
+FILEVERSION 1,2,3,4
+PRODUCTVERSION 1,2,0,0
+FILEFLAGSMASK VS_FFI_FILEFLAGSMASK
+FILEFLAGS 0x0L
+FILEOS VOS_NT_WINDOWS32
+FILETYPE VFT_APP
+FILESUBTYPE 0x0L
+BEGIN
+    BLOCK "StringFileInfo"
+    BEGIN
+        BLOCK "040904b0"
+        BEGIN
+            VALUE "CompanyName", "Example Corp"
+            VALUE "FileVersion", "1.2.3.4"
+            VALUE "X-Custom", "dropped"
+        END
+    END
+    BLOCK "VarFileInfo"
+    BEGIN
+        VALUE "Translation", 0x409, 1200
+    END
+END
+`
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "app.rc")
+	require.NoError(t, os.WriteFile(script, []byte(testResourceScript), 0644))
+
+	t.Run("file", func(t *testing.T) {
+		output := filepath.Join(dir, "versioninfo.yaml")
+		code, _, stderr := runApp("import", "-o", output, script)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stderr, script+": StringFileInfo.X-Custom is not kept")
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "CompanyName: Example Corp")
+
+		info := readVersionInfoFile(t, output)
+		assert.Equal(t, 4, info.FixedFileInfo.FileVersion.Build)
+		assert.Equal(t, model.DefaultInfo().FixedFileInfo.FileOS, info.FixedFileInfo.FileOS)
+
+		code, _, stderr = runApp("import", "-o", output, script)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "already exists")
+	})
+
+	t.Run("round trip through stdio", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "2.0.0")
+		code, exported, stderr := runApp("export", fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		code, stdout, stderr := runAppWithStdin(exported, "import", "-to", "json", "-o", "-", "-")
+		require.Equal(t, exitOK, code, stderr)
+		info, err := model.ParseVersionInfo([]byte(stdout))
+		require.NoError(t, err)
+		assert.Equal(t, readVersionInfoFile(t, fileName).StringFileInfo, info.StringFileInfo)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		code, result := runAppReport(t, "import", "-output-format", "json", "-o", filepath.Join(t.TempDir(), "versioninfo.json"), script)
+		require.Equal(t, exitOK, code)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, []string{"StringFileInfo.X-Custom is not kept"}, result.Files[0].Warnings)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		code, _, _ := runApp("import")
+		assert.Equal(t, exitUsage, code)
+
+		invalid := filepath.Join(dir, "invalid.rc")
+		require.NoError(t, os.WriteFile(invalid, []byte("1 VERSIONINFO\nFILEDATE 0\n"), 0644))
+		code, result := runAppReport(t, "import", "-output-format", "json", "-o", filepath.Join(t.TempDir(), "versioninfo.json"), invalid)
+		assert.Equal(t, exitFailure, code)
+		assert.Equal(t, &errorReport{Code: errorParse, Message: result.Error.Message, File: invalid, Line: 2, Column: 1}, result.Error)
+
+		empty := filepath.Join(dir, "empty.rc")
+		require.NoError(t, os.WriteFile(empty, []byte("IDI_ICON1 ICON \"app.ico\"\n"), 0644))
+		code, result = runAppReport(t, "import", "-output-format", "json", "-o", filepath.Join(t.TempDir(), "versioninfo.json"), empty)
+		assert.Equal(t, exitFailure, code)
+		assert.Equal(t, errorNoVersionInfo, result.Error.Code)
+	})
+}
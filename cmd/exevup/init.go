@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func (a app) init(args []string) error {
+	fs := a.newFlagSet("init", "exevup init [flags] [file]", "Create a new version info file, which is versioninfo.json by default.\nWith -template, the file is a copy of the template with the given version and fields.")
+
+	versionValue := fs.String("version", "1.0.0.0", "initial version")
+	fs.StringVar(versionValue, "v", *versionValue, "alias for -version")
+
+	notationValue := fs.String("notation", "", "notation for version - simple/normal/detail/semver, blank for as written")
+	fs.StringVar(notationValue, "n", *notationValue, "alias for -notation")
+
+	fields := a.stringFieldFlags(fs)
+
+	templateName := fs.String("template", "", "version info file to start from instead of the default")
+
+	force := fs.Bool("force", false, "overwrite the file if it already exists")
+	fs.BoolVar(force, "f", *force, "alias for -force")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	if err = a.checkStdio(fs, []string{fileName}, fileName); err != nil {
+		return err
+	}
+	if *notationValue != "" {
+		if _, err = a.notationOf(fs, *notationValue); err != nil {
+			return err
+		}
+	}
+
+	if !*force && fileExists(fileName) {
+		return fmt.Errorf("%s already exists, use -force to overwrite", fileName)
+	}
+
+	var template []byte
+	info := model.DefaultInfo()
+	if *templateName != "" {
+		if template, info, err = a.readVersionInfo(*templateName, false); err != nil {
+			return err
+		}
+	}
+
+	//템플릿의 버전은 -version을 준 경우에만 바꿈
+	if *templateName == "" || isFlagSet(fs, "version") || isFlagSet(fs, "v") {
+		if info, err = versionSet(info, *versionValue, model.TargetBoth, model.VersionNotation(*notationValue)); err != nil {
+			return err
+		}
+	}
+
+	if info, err = fields.updated(fs, info); err != nil {
+		return err
+	}
+
+	data, err := a.versionInfoDataOf(*templateName, fileName, template, info, false)
+	if err != nil {
+		return err
+	}
+
+	if fileName == stdio {
+		_, err = a.stdout.Write(data)
+	} else {
+		err = writeVersionInfoData(fileName, data, false)
+	}
+	if err != nil {
+		return err
+	}
+	report := fileReport{Input: fileName, After: versionReportOf(info), Written: []string{fileName}}
+	if *templateName != "" {
+		report.Input, report.Output = *templateName, fileName
+	}
+	a.reportFile(report)
+	return nil
+}
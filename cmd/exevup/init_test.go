@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+
+	code, _, stderr := runApp("init", "-version", "0.1.0", "-company", "Example Corp", "-product-name", "Example", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info := readVersionInfoFile(t, fileName)
+	assert.Equal(t, "0.1.0", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "0.1.0", info.StringFileInfo.ProductVersion)
+	assert.Equal(t, 1, info.FixedFileInfo.ProductVersion.Minor)
+	assert.Equal(t, "Example Corp", info.StringFileInfo.CompanyName)
+	assert.Equal(t, "Example", info.StringFileInfo.ProductName)
+	assert.Equal(t, "040004", info.FixedFileInfo.FileOS)
+
+	t.Run("existing file", func(t *testing.T) {
+		code, _, stderr := runApp("init", fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "already exists")
+	})
+
+	t.Run("existing file with force", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(fileName, []byte("{}"), 0644))
+
+		code, _, stderr := runApp("init", "-f", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.0.0.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		templateName := filepath.Join(t.TempDir(), "template.json")
+		require.NoError(t, os.WriteFile(templateName, []byte(`{
+  "StringFileInfo": {
+    "CompanyName": "Template Corp",
+    "FileVersion": "2.0.0"
+  },
+  "X-Custom": true
+}
+`), 0644))
+		outputName := filepath.Join(t.TempDir(), "versioninfo.json")
+
+		code, _, stderr := runApp("init", "-template", templateName, "-product-name", "Example", outputName)
+		require.Equal(t, exitOK, code, stderr)
+
+		data, err := os.ReadFile(outputName)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"X-Custom": true`)
+		info := readVersionInfoFile(t, outputName)
+		assert.Equal(t, "2.0.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, "Template Corp", info.StringFileInfo.CompanyName)
+		assert.Equal(t, "Example", info.StringFileInfo.ProductName)
+
+		code, _, stderr = runApp("init", "-f", "-template", templateName, "-v", "3.1.0", outputName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "3.1.0", readVersionInfoFile(t, outputName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		code, _, stderr := runApp("init", "-f", "-template", filepath.Join(t.TempDir(), "missing.json"), fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "file not found")
+	})
+}
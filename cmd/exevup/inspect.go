@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/simp7/goversioninfo-toolkit/peversion"
+)
+
+func (a app) inspect(args []string) error {
+	fs := a.newFlagSet("inspect", "exevup inspect [flags] {file}", "Print the version resource of a compiled Windows executable, library or .syso file.")
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		return a.usageError(fs, "file is required")
+	}
+
+	fileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	info, err := peversion.Open(fileName)
+	if err != nil {
+		return err
+	}
+
+	a.reportFile(fileReport{Input: fileName, Info: infoReportOf(info)})
+	return writeInfo(a.stdout, info)
+}
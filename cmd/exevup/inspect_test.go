@@ -0,0 +1,31 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	info := model.DefaultInfo()
+	info.StringFileInfo.ProductName = "Example"
+	vi := goversioninfo.VersionInfo(info)
+	vi.Build()
+	vi.Walk()
+
+	fileName := filepath.Join(t.TempDir(), "resource.syso")
+	require.NoError(t, vi.WriteSyso(fileName, "amd64"))
+
+	code, stdout, stderr := runApp("inspect", fileName)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Regexp(t, `\n  ProductName +Example\n`, stdout)
+	assert.Contains(t, stdout, "  Translation  0409 04B0\n")
+
+	code, _, stderr = runApp("inspect")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "file is required")
+}
@@ -1,111 +1,223 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"io"
-	"log"
 	"os"
+	"strings"
 
 	"github.com/simp7/goversioninfo-toolkit/model"
 )
 
-func parseVersionInfoFromFile(fileName string) (model.Info, error) {
-	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
-		return model.Info{}, err
-	}
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+	exitInvalid = 3
+)
 
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
+const defaultFileName = "versioninfo.json"
 
-	data, err := io.ReadAll(file)
-	if err != nil {
-		return model.Info{}, err
-	}
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	out    *output
+	// format is set by -format, overriding the format of version info files chosen by their extension.
+	format *model.Format
+}
 
-	return model.ParseVersionInfo(data)
+type command struct {
+	name    string
+	summary string
+	run     func(a app, args []string) error
 }
 
-func overwriteVersionInfoToFile(fileName string, info model.Info) error {
-	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		return err
-	}
+var commands = []command{
+	{name: "bump", summary: "bump the version, which is the default when no command is given", run: app.bump},
+	{name: "get", summary: "print the current file and product version", run: app.get},
+	{name: "set", summary: "assign an explicit version", run: app.set},
+	{name: "init", summary: "create a new version info file", run: app.init},
+	{name: "show", summary: "print all fields of the version info", run: app.show},
+	{name: "validate", summary: "check the version info for problems", run: app.validate},
+	{name: "sync", summary: "set the canonical version of the workspace to every file in it", run: app.sync},
+	{name: "inspect", summary: "print the version resource of a compiled executable", run: app.inspect},
+	{name: "render", summary: "render templates in StringFileInfo values into another file", run: app.render},
+	{name: "copyright", summary: "extend the year of LegalCopyright to the current year", run: app.copyright},
+	{name: "convert", summary: "convert the version info between json, yaml and toml", run: app.convert},
+	{name: "export", summary: "export the version info as a resource script of rc and windres", run: app.export},
+	{name: "import", summary: "import the version info from a resource script of rc and windres", run: app.importScript},
+}
 
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
+type exitError struct {
+	code int
+	err  error
+}
 
-	err = file.Truncate(0)
-	if err != nil {
-		log.Println(err)
-	}
+func (e exitError) Error() string {
+	return e.err.Error()
+}
 
-	data, err := model.StringifyVersionInfo(model.Info(info))
-	if err != nil {
-		return err
+func (e exitError) Unwrap() error {
+	return e.err
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
 	}
+	return command{}, false
+}
 
-	_, err = file.Write(data)
-	return err
+func (a app) newFlagSet(name string, usage string, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %s\n\n%s\n\nflags:\n", usage, description)
+		fs.PrintDefaults()
+	}
+	a.outputFlag(fs)
+	return fs
 }
 
-func main() {
-	notationValue := flag.String("notation", string(model.NotationNormal), "notation for version - simple/normal/detail")
-	flag.StringVar(notationValue, "n", *notationValue, "alias for -notation")
+// parseFlags parses flags placed both before and after positional arguments, so that
+// `exevup versioninfo.json -l minor` works as well as `exevup -l minor versioninfo.json`.
+func (a app) parseFlags(fs *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err = fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, exitError{code: exitUsage, err: err}
+		}
 
-	levelValue := flag.String("level", string(model.LevelPatch), "level for versioning - major/minor/patch/build")
-	flag.StringVar(levelValue, "l", *levelValue, "alias for -level")
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, a.checkOutputFormat(fs)
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (a app) usageError(fs *flag.FlagSet, format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	fmt.Fprintf(fs.Output(), "%s\n", err)
+	fs.Usage()
+	return exitError{code: exitUsage, err: err}
+}
 
-	targetValue := flag.String("target", string(model.TargetBoth), "target for versioning - both/file/product")
-	flag.StringVar(targetValue, "t", *targetValue, "alias for -target")
+func (a app) fileNameOf(fs *flag.FlagSet, positional []string) (string, error) {
+	switch len(positional) {
+	case 0:
+		return defaultFileName, nil
+	case 1:
+		return positional[0], nil
+	default:
+		return "", a.usageError(fs, "too many arguments: %s", strings.Join(positional, " "))
+	}
+}
 
-	outputName := flag.String("output", "", "output file name, blank for input itself")
-	flag.StringVar(outputName, "o", *outputName, "alias for -output")
+func (a app) targetOf(fs *flag.FlagSet, value string) (model.VersionTarget, error) {
+	switch target := model.VersionTarget(value); target {
+	case model.TargetBoth, model.TargetFile, model.TargetProduct:
+		return target, nil
+	}
+	return "", a.usageError(fs, "unknown target %q", value)
+}
 
-	flag.Parse()
+func (a app) levelOf(fs *flag.FlagSet, value string) (model.VersionLevel, error) {
+	switch level := model.VersionLevel(value); level {
+	case model.LevelMajor, model.LevelMinor, model.LevelPatch, model.LevelBuild, model.LevelPreRelease, model.LevelRelease, levelAuto:
+		return level, nil
+	}
+	return "", a.usageError(fs, "unknown level %q", value)
+}
 
-	notation := model.VersionNotation(*notationValue)
-	level := model.VersionLevel(*levelValue)
-	target := model.VersionTarget(*targetValue)
+func (a app) notationOf(fs *flag.FlagSet, value string) (model.VersionNotation, error) {
+	switch notation := model.VersionNotation(value); {
+	case notation == model.NotationSimple, notation == model.NotationNormal, notation == model.NotationDetail, notation == model.NotationSemVer, notation.IsTemplate():
+		return notation, nil
+	}
+	return "", a.usageError(fs, "unknown notation %q", value)
+}
 
-	inputFileName := "versioninfo.json"
-	args := flag.Args()
+func (a app) help(args []string) error {
 	if len(args) >= 1 {
-		inputFileName = args[0]
-	}
-	outputFileName := inputFileName
-	if *outputName != "" {
-		outputFileName = *outputName
+		cmd, ok := findCommand(args[0])
+		if !ok {
+			err := fmt.Errorf("unknown command %q", args[0])
+			fmt.Fprintf(a.stderr, "exevup: %s\n", err)
+			return exitError{code: exitUsage, err: err}
+		}
+		return cmd.run(a, []string{"-h"})
 	}
 
-	info, err := parseVersionInfoFromFile(inputFileName)
-	if err != nil {
-		log.Fatal(err)
+	fmt.Fprintf(a.stdout, "usage: exevup [command] [flags] [file]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stdout, "  %-10s%s\n", cmd.name, cmd.summary)
 	}
+	fmt.Fprintf(a.stdout, "\nRun 'exevup help [command]' for the flags of each command.\n")
+	return nil
+}
 
-	fileVersion, err := info.GetFileVersion()
-	if err != nil {
-		log.Fatal(err)
+func (a app) exitCodeOf(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
 	}
 
-	productVersion, err := info.GetProductVersion()
-	if err != nil {
-		log.Fatal(err)
+	var exitErr exitError
+	if errors.As(err, &exitErr) {
+		//사용법 오류는 flag 패키지에서 이미 출력함
+		if exitErr.code != exitUsage {
+			fmt.Fprintf(a.stderr, "exevup: %s\n", err)
+		}
+		return exitErr.code
 	}
 
-	fileVersion = fileVersion.Updated(level)
-	productVersion = productVersion.Updated(level)
+	fmt.Fprintf(a.stderr, "exevup: %s\n", err)
 
-	info = info.VersionUpdated(fileVersion, productVersion, target, notation)
+	var validationErr model.ValidationError
+	if errors.As(err, &validationErr) {
+		return exitInvalid
+	}
+	return exitFailure
+}
 
-	if err = overwriteVersionInfoToFile(outputFileName, info); err != nil {
-		log.Fatal(err)
+// runCommand runs cmd and prints its report with -output-format json.
+func (a app) runCommand(cmd command, args []string) int {
+	a.out = &output{w: a.stdout, format: outputFormatText}
+	a.stdout = a.out
+	a.format = new(model.Format)
+
+	err := cmd.run(a, args)
+	code := a.exitCodeOf(err)
+	if a.out.format == outputFormatJSON {
+		if err = a.out.writeReport(cmd.name, err); err != nil {
+			fmt.Fprintf(a.stderr, "exevup: %s\n", err)
+			return exitFailure
+		}
 	}
+	return code
+}
 
+func (a app) run(args []string) int {
+	if len(args) >= 1 {
+		if args[0] == "help" {
+			return a.exitCodeOf(a.help(args[1:]))
+		}
+		if cmd, ok := findCommand(args[0]); ok {
+			return a.runCommand(cmd, args[1:])
+		}
+	}
+	bump, _ := findCommand("bump")
+	return a.runCommand(bump, args)
+}
+
+func main() {
+	os.Exit(app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}.run(os.Args[1:]))
 }
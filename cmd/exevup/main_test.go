@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/simp7/goversioninfo-toolkit/model"
@@ -10,100 +12,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParseVersionInfoFromFile(t *testing.T) {
-	// Create a temporary test file
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "test_versioninfo.json")
-
-	validContent := `{
-		"FixedFileInfo": {
-			"FileVersion": {
-				"Major": 1,
-				"Minor": 2,
-				"Patch": 3,
-				"Build": 4
-			},
-			"ProductVersion": {
-				"Major": 1,
-				"Minor": 2,
-				"Patch": 3,
-				"Build": 4
-			}
-		},
-		"StringFileInfo": {
-			"FileVersion": "1.2.3.4",
-			"ProductVersion": "1.2.3.4"
-		}
-	}`
-
-	t.Run("valid file", func(t *testing.T) {
-		err := os.WriteFile(testFile, []byte(validContent), 0644)
-		require.NoError(t, err)
-
-		info, err := parseVersionInfoFromFile(testFile)
-		require.NoError(t, err)
-		assert.Equal(t, 1, info.FixedFileInfo.FileVersion.Major)
-		assert.Equal(t, "1.2.3.4", info.StringFileInfo.FileVersion)
-	})
-
-	t.Run("non-existent file creates empty file", func(t *testing.T) {
-		nonExistentFile := filepath.Join(tempDir, "non_existent.json")
-
-		// Write empty JSON object to make parsing successful
-		err := os.WriteFile(nonExistentFile, []byte("{}"), 0644)
-		require.NoError(t, err)
-
-		info, err := parseVersionInfoFromFile(nonExistentFile)
-		require.NoError(t, err)
-
-		// File should be created but empty, so version info should be empty
-		assert.Equal(t, 0, info.FixedFileInfo.FileVersion.Major)
-
-		// Clean up
-		os.Remove(nonExistentFile)
-	})
-
-	t.Run("invalid JSON file", func(t *testing.T) {
-		invalidFile := filepath.Join(tempDir, "invalid.json")
-		err := os.WriteFile(invalidFile, []byte("{invalid json"), 0644)
-		require.NoError(t, err)
-
-		_, err = parseVersionInfoFromFile(invalidFile)
-		assert.Error(t, err)
-	})
-}
-
-func TestOverwriteVersionInfoToFile(t *testing.T) {
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "output_test.json")
-
-	// Create a minimal valid info object
-	info := model.Info{}
-
-	t.Run("write to new file", func(t *testing.T) {
-		err := overwriteVersionInfoToFile(testFile, info)
-		assert.NoError(t, err)
-
-		// Verify file was created
-		_, err = os.Stat(testFile)
-		assert.NoError(t, err)
-	})
-
-	t.Run("overwrite existing file", func(t *testing.T) {
-		// Write some content first
-		err := os.WriteFile(testFile, []byte("existing content"), 0644)
-		require.NoError(t, err)
-
-		err = overwriteVersionInfoToFile(testFile, info)
-		assert.NoError(t, err)
-
-		// Read back and verify it was overwritten
-		content, err := os.ReadFile(testFile)
-		require.NoError(t, err)
-		assert.NotContains(t, string(content), "existing content")
-	})
-}
-
 func TestIntegrationVersionUpdate(t *testing.T) {
 	tempDir := t.TempDir()
 	inputFile := filepath.Join(tempDir, "integration_test.json")
@@ -136,7 +44,7 @@ func TestIntegrationVersionUpdate(t *testing.T) {
 
 	t.Run("complete workflow", func(t *testing.T) {
 		// Parse input file
-		info, err := parseVersionInfoFromFile(inputFile)
+		_, info, err := app{}.readVersionInfo(inputFile, false)
 		require.NoError(t, err)
 
 		// Get versions
@@ -153,12 +61,10 @@ func TestIntegrationVersionUpdate(t *testing.T) {
 		updatedInfo := info.VersionUpdated(updatedFileVersion, updatedProductVersion, "both", "detail")
 
 		// Write to output file
-		err = overwriteVersionInfoToFile(outputFile, updatedInfo)
-		require.NoError(t, err)
+		writeVersionInfoFile(t, outputFile, updatedInfo)
 
 		// Verify the update
-		resultInfo, err := parseVersionInfoFromFile(outputFile)
-		require.NoError(t, err)
+		resultInfo := readVersionInfoFile(t, outputFile)
 
 		resultFileVersion, err := resultInfo.GetFileVersion()
 		require.NoError(t, err)
@@ -177,3 +83,131 @@ func TestIntegrationVersionUpdate(t *testing.T) {
 		assert.Equal(t, 0, resultProductVersion.Build) // Reset to 0
 	})
 }
+
+func runApp(args ...string) (code int, stdout string, stderr string) {
+	return runAppWithStdin("", args...)
+}
+
+func runAppWithStdin(stdin string, args ...string) (code int, stdout string, stderr string) {
+	var out, errOut bytes.Buffer
+	code = app{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut}.run(args)
+	return code, out.String(), errOut.String()
+}
+
+func newVersionInfoFile(t *testing.T, version string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+	info, err := versionSet(model.Info{}, version, model.TargetBoth, "")
+	require.NoError(t, err)
+	writeVersionInfoFile(t, fileName, info)
+	return fileName
+}
+
+func readVersionInfoFile(t *testing.T, fileName string) model.Info {
+	t.Helper()
+
+	_, info, err := app{}.readVersionInfo(fileName, false)
+	require.NoError(t, err)
+	return info
+}
+
+func writeVersionInfoFile(t *testing.T, fileName string, info model.Info) {
+	t.Helper()
+
+	codec, err := model.CodecOf(model.FormatOf(fileName))
+	require.NoError(t, err)
+	data, err := codec.Encode(info)
+	require.NoError(t, err)
+	require.NoError(t, writeVersionInfoFiles([]pendingWrite{{fileName: fileName, data: data}}, false))
+}
+
+func TestRun(t *testing.T) {
+	t.Run("bare invocation bumps with flags after file name", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, _, stderr := runApp(fileName, "-l", "minor")
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.3.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("bump command", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, _, stderr := runApp("bump", "-l", "major", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "2.0.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("help", func(t *testing.T) {
+		code, stdout, _ := runApp("help")
+		assert.Equal(t, exitOK, code)
+		for _, cmd := range commands {
+			assert.Contains(t, stdout, cmd.name)
+		}
+	})
+
+	t.Run("help for command", func(t *testing.T) {
+		code, _, stderr := runApp("help", "get")
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, stderr, "usage: exevup get")
+	})
+
+	t.Run("help for unknown command", func(t *testing.T) {
+		code, _, stderr := runApp("help", "unknown")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "unknown command")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		code, _, stderr := runApp("get", "-unknown")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "flag provided but not defined")
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		code, _, stderr := runApp("show", "a.json", "b.json")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "too many arguments")
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		code, _, stderr := runApp("get", "-t", "neither")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "unknown target")
+	})
+
+	t.Run("unknown level and notation", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.0.0.0")
+		for _, args := range [][]string{
+			{"-l", "mnor"},
+			{"bump", "-file-level", "bogus"},
+			{"bump", "-product-level", "bogus"},
+			{"bump", "-n", "nrmal"},
+			{"bump", "-file-notation", "nrmal"},
+			{"set", "-v", "2.0.0", "-n", "nrmal"},
+		} {
+			code, _, stderr := runApp(append(args, fileName)...)
+			assert.Equal(t, exitUsage, code, args)
+			assert.Regexp(t, "unknown (level|notation)", stderr, args)
+		}
+		assert.Equal(t, "1.0.0.0", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+
+		code, _, stderr := runApp("bump", "-l", "build", "-n", "template:{{.Major}}.{{.Minor}}.{{.Patch}}.{{.Build}}", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.0.0.1", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+
+		//auto는 저장소가 없어 실패하지만 사용법 오류는 아님
+		code, _, stderr = runApp("bump", "-file-level", "auto", fileName)
+		assert.NotEqual(t, exitUsage, code, stderr)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(fileName, []byte("{invalid json"), 0644))
+
+		code, _, stderr := runApp("get", fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "exevup: ")
+	})
+}
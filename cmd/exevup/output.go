@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/simp7/goversioninfo-toolkit/gitversion"
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/simp7/goversioninfo-toolkit/workspace"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// Error codes of errorReport, which are stable so that scripts can match them.
+const (
+	errorUsage              = "usage"
+	errorFailure            = "failure"
+	errorNotFound           = "not-found"
+	errorParse              = "parse-error"
+	errorInvalid            = "invalid"
+	errorCheckFailed        = "check-failed"
+	errorInvalidVersion     = "invalid-version"
+	errorVersionOutOfRange  = "version-out-of-range"
+	errorInvalidTemplate    = "invalid-template"
+	errorUndefinedVariable  = "undefined-variable"
+	errorNoTag              = "no-tag"
+	errorInvalidWorkspace   = "invalid-workspace"
+	errorUnknownStringField = "unknown-field"
+	errorUnknownFormat      = "unknown-format"
+	errorNoVersionInfo      = "no-version-info"
+)
+
+type versionReport struct {
+	File    string `json:"file"`
+	Product string `json:"product"`
+}
+
+func versionReportOf(info model.Info) *versionReport {
+	return &versionReport{
+		File:    versionStringOf(info.StringFileInfo.FileVersion, model.Version(info.FixedFileInfo.FileVersion), false),
+		Product: versionStringOf(info.StringFileInfo.ProductVersion, model.Version(info.FixedFileInfo.ProductVersion), false),
+	}
+}
+
+// infoReport is the configuration of a version info, leaving out Timestamp, Buffer and Structure,
+// which goversioninfo fills while building.
+type infoReport struct {
+	FixedFileInfo  goversioninfo.FixedFileInfo  `json:"FixedFileInfo"`
+	StringFileInfo goversioninfo.StringFileInfo `json:"StringFileInfo"`
+	VarFileInfo    goversioninfo.VarFileInfo    `json:"VarFileInfo"`
+	IconPath       string                       `json:"IconPath"`
+	ManifestPath   string                       `json:"ManifestPath"`
+}
+
+func infoReportOf(info model.Info) *infoReport {
+	return &infoReport{
+		FixedFileInfo:  info.FixedFileInfo,
+		StringFileInfo: info.StringFileInfo,
+		VarFileInfo:    info.VarFileInfo,
+		IconPath:       info.IconPath,
+		ManifestPath:   info.ManifestPath,
+	}
+}
+
+type errorReport struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// errorReportOf returns err with the code of its kind, and the position of parse errors.
+func errorReportOf(err error) *errorReport {
+	result := &errorReport{Code: errorFailure, Message: err.Error()}
+
+	var exitErr exitError
+	var notFoundErr model.NotFoundError
+	var parseErr model.ParseError
+	var validationErr model.ValidationError
+	switch {
+	case errors.As(err, &notFoundErr):
+		result.Code, result.File = errorNotFound, notFoundErr.FileName
+	case errors.As(err, &parseErr):
+		result.Code, result.File, result.Line, result.Column = errorParse, parseErr.FileName, parseErr.Line, parseErr.Column
+	case errors.As(err, &validationErr):
+		result.Code, result.File = errorInvalid, validationErr.FileName
+	case errors.Is(err, model.ErrVersionOutOfRange):
+		result.Code = errorVersionOutOfRange
+	case errors.Is(err, model.ErrUndefinedVariable):
+		result.Code = errorUndefinedVariable
+	case errors.Is(err, model.ErrInvalidTemplate):
+		result.Code = errorInvalidTemplate
+	case errors.Is(err, model.ErrInvalidSemVer), errors.Is(err, model.ErrInvalidStringVersion), errors.Is(err, model.ErrUnknownComponent):
+		result.Code = errorInvalidVersion
+	case errors.Is(err, model.ErrUnknownStringField):
+		result.Code = errorUnknownStringField
+	case errors.Is(err, model.ErrUnknownFormat):
+		result.Code = errorUnknownFormat
+	case errors.Is(err, model.ErrNoVersionInfo):
+		result.Code = errorNoVersionInfo
+	case errors.Is(err, gitversion.ErrNoTag):
+		result.Code = errorNoTag
+	case errors.Is(err, workspace.ErrInvalidConfig):
+		result.Code = errorInvalidWorkspace
+	case errors.As(err, &exitErr) && exitErr.code == exitUsage:
+		result.Code = errorUsage
+	case errors.As(err, &exitErr) && exitErr.code == exitInvalid:
+		result.Code = errorCheckFailed
+	}
+	return result
+}
+
+// fileReport is the result of a command for one file. Fields which do not apply to the command are omitted.
+type fileReport struct {
+	Input    string         `json:"input"`
+	Output   string         `json:"output,omitempty"`
+	Before   *versionReport `json:"before,omitempty"`
+	After    *versionReport `json:"after,omitempty"`
+	Version  *versionReport `json:"version,omitempty"`
+	Info     *infoReport    `json:"info,omitempty"`
+	Valid    *bool          `json:"valid,omitempty"`
+	Issues   []model.Issue  `json:"issues,omitempty"`
+	Diff     string         `json:"diff,omitempty"`
+	Written  []string       `json:"written,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Error    *errorReport   `json:"error,omitempty"`
+}
+
+// report is what -output-format json prints instead of the text output, once the command finishes.
+type report struct {
+	Command  string       `json:"command"`
+	OK       bool         `json:"ok"`
+	Target   string       `json:"target,omitempty"`
+	Notation string       `json:"notation,omitempty"`
+	DryRun   bool         `json:"dryRun,omitempty"`
+	Files    []fileReport `json:"files"`
+	Error    *errorReport `json:"error,omitempty"`
+}
+
+// output is the stdout of app, which discards the text output with -output-format json
+// while the command reports its result instead.
+type output struct {
+	w      io.Writer
+	format string
+	report report
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	if o.format == outputFormatJSON {
+		return len(p), nil
+	}
+	return o.w.Write(p)
+}
+
+func (o *output) writeReport(command string, err error) error {
+	o.report.Command = command
+	o.report.OK = err == nil
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		o.report.Error = errorReportOf(err)
+	}
+	if o.report.Files == nil {
+		o.report.Files = []fileReport{}
+	}
+
+	encoder := json.NewEncoder(o.w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(o.report)
+}
+
+func (a app) outputFlag(fs *flag.FlagSet) {
+	if a.out != nil {
+		fs.StringVar(&a.out.format, "output-format", outputFormatText, "output format - text/json")
+	}
+}
+
+func (a app) checkOutputFormat(fs *flag.FlagSet) error {
+	if a.out == nil {
+		return nil
+	}
+
+	switch a.out.format {
+	case outputFormatText, outputFormatJSON:
+		return nil
+	}
+	format := a.out.format
+	a.out.format = outputFormatText
+	return a.usageError(fs, "unknown output format %q", format)
+}
+
+func (a app) reportOptions(target model.VersionTarget, notation model.VersionNotation, dryRun bool) {
+	if a.out != nil {
+		a.out.report.Target, a.out.report.Notation, a.out.report.DryRun = string(target), string(notation), dryRun
+	}
+}
+
+func (a app) reportFile(file fileReport) {
+	if a.out != nil {
+		a.out.report.Files = append(a.out.report.Files, file)
+	}
+}
+
+// warningsOf splits the log of a result into warnings.
+func warningsOf(log string) (result []string) {
+	for _, line := range strings.Split(log, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorReportOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected errorReport
+	}{
+		{
+			name:     "not found",
+			err:      model.NotFoundError{FileName: "a.json"},
+			expected: errorReport{Code: errorNotFound, Message: "a.json: file not found", File: "a.json"},
+		},
+		{
+			name:     "parse error",
+			err:      fmt.Errorf("reading: %w", model.ParseError{FileName: "a.json", Line: 2, Column: 3, Err: errors.New("invalid character")}),
+			expected: errorReport{Code: errorParse, Message: "reading: a.json:2:3: invalid character", File: "a.json", Line: 2, Column: 3},
+		},
+		{
+			name:     "out of range",
+			err:      fmt.Errorf("%w: too big", model.ErrVersionOutOfRange),
+			expected: errorReport{Code: errorVersionOutOfRange, Message: "version component out of range: too big"},
+		},
+		{
+			name:     "unknown format",
+			err:      fmt.Errorf("%w \"xml\"", model.ErrUnknownFormat),
+			expected: errorReport{Code: errorUnknownFormat, Message: "unknown version info format \"xml\""},
+		},
+		{
+			name:     "check",
+			err:      exitError{code: exitInvalid, err: errors.New("stale")},
+			expected: errorReport{Code: errorCheckFailed, Message: "stale"},
+		},
+		{
+			name:     "other",
+			err:      errors.New("disk full"),
+			expected: errorReport{Code: errorFailure, Message: "disk full"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, *errorReportOf(tt.err))
+		})
+	}
+}
+
+func runAppReport(t *testing.T, args ...string) (int, report) {
+	t.Helper()
+
+	code, stdout, stderr := runApp(args...)
+	var result report
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result), "stdout: %s\nstderr: %s", stdout, stderr)
+	return code, result
+}
+
+func TestOutputFormatJSON(t *testing.T) {
+	t.Run("bump", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, result := runAppReport(t, "bump", "-output-format", "json", "-l", "minor", fileName)
+		require.Equal(t, exitOK, code)
+		assert.Equal(t, report{
+			Command:  "bump",
+			OK:       true,
+			Target:   "both",
+			Notation: "normal",
+			Files: []fileReport{{
+				Input:   fileName,
+				Output:  fileName,
+				Before:  &versionReport{File: "1.2.3", Product: "1.2.3"},
+				After:   &versionReport{File: "1.3.0", Product: "1.3.0"},
+				Written: []string{fileName},
+			}},
+		}, result)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, result := runAppReport(t, "-output-format", "json", "-dry-run", fileName)
+		require.Equal(t, exitOK, code)
+		assert.True(t, result.DryRun)
+		require.Len(t, result.Files, 1)
+		assert.Contains(t, result.Files[0].Diff, "+\t\t\"FileVersion\": \"1.2.4\"")
+		assert.Empty(t, result.Files[0].Written)
+	})
+
+	t.Run("failed file", func(t *testing.T) {
+		good := newVersionInfoFile(t, "1.2.3")
+		bad := filepath.Join(t.TempDir(), "versioninfo.json")
+		require.NoError(t, os.WriteFile(bad, []byte("{\n  \"StringFileInfo\": ,\n}"), 0644))
+
+		code, result := runAppReport(t, "-output-format", "json", good, bad)
+		assert.Equal(t, exitFailure, code)
+		assert.False(t, result.OK)
+		assert.Equal(t, errorFailure, result.Error.Code)
+		require.Len(t, result.Files, 2)
+		assert.Nil(t, result.Files[0].Error)
+		assert.Equal(t, &errorReport{Code: errorParse, Message: result.Files[1].Error.Message, File: bad, Line: 2, Column: 21}, result.Files[1].Error)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, result := runAppReport(t, "get", "-output-format", "json", fileName)
+		require.Equal(t, exitOK, code)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, &versionReport{File: "1.2.3", Product: "1.2.3"}, result.Files[0].Version)
+	})
+
+	t.Run("show", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, stdout, stderr := runApp("show", "-output-format", "json", fileName)
+		require.Equal(t, exitOK, code, stderr)
+		var result struct {
+			Files []struct {
+				Info map[string]json.RawMessage `json:"info"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+		require.Len(t, result.Files, 1)
+
+		var keys []string
+		for key := range result.Files[0].Info {
+			keys = append(keys, key)
+		}
+		assert.ElementsMatch(t, []string{"FixedFileInfo", "StringFileInfo", "VarFileInfo", "IconPath", "ManifestPath"}, keys)
+		assert.Contains(t, string(result.Files[0].Info["StringFileInfo"]), `"FileVersion": "1.2.3"`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "missing.json")
+
+		code, result := runAppReport(t, "set", "-output-format", "json", "-v", "1.0.0", fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Equal(t, []fileReport{}, result.Files)
+		assert.Equal(t, &errorReport{Code: errorNotFound, Message: fileName + ": file not found", File: fileName}, result.Error)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		code, stdout, stderr := runApp("get", "-output-format", "yaml", newVersionInfoFile(t, "1.2.3"))
+		assert.Equal(t, exitUsage, code)
+		assert.Equal(t, "", stdout)
+		assert.Contains(t, stderr, "unknown output format")
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func (a app) render(args []string) error {
+	fs := a.newFlagSet("render", "exevup render [flags] -o {output} [file]", "Render the text/template in each StringFileInfo value of file, which is versioninfo.json by default, to output.\n"+
+		"Values can reference -var variables, StringFileInfo values by name, {{.Company}}, {{.Product}}, {{.Year}}, {{.Date}} and {{env \"NAME\"}}.\n"+
+		"An undefined variable is an error, and nothing is written.")
+
+	outputName := fs.String("output", "", "output file name, - for stdout, which must not be the input")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	var vars assignments
+	fs.Var(&vars, "var", "variable to define as Key=Value, can be repeated")
+
+	dateValue := fs.String("date", "", "date for {{.Year}} and {{.Date}} in YYYY-MM-DD, blank for today")
+
+	syso := a.sysoFlags(fs)
+
+	reformat := fs.Bool("reformat", false, "write the whole file instead of only the rendered values")
+	backup := fs.Bool("backup", false, "keep the previous content of the output file as .bak")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a unified diff instead of writing any file")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	inputFileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	//템플릿 원본을 덮어쓰지 않도록 출력 파일을 따로 받음
+	if *outputName == "" || (inputFileName != stdio && filepath.Clean(*outputName) == filepath.Clean(inputFileName)) {
+		return a.usageError(fs, "-output must be given and differ from the input, so that the template is kept")
+	}
+	if err = a.checkStdio(fs, []string{inputFileName}, *outputName); err != nil {
+		return err
+	}
+
+	archs, err := a.archsOf(fs, syso.archs)
+	if err != nil {
+		return err
+	}
+
+	date, err := dateOf(*dateValue)
+	if err != nil {
+		return err
+	}
+
+	context := model.RenderContext{Vars: make(map[string]string, len(vars)), Date: date, Env: os.LookupEnv}
+	for _, assignment := range vars {
+		key, value, _ := strings.Cut(assignment, "=")
+		context.Vars[key] = value
+	}
+
+	original, info, err := a.readVersionInfo(inputFileName, false)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := info.Rendered(context)
+	if err != nil {
+		return fmt.Errorf("%s: %w", inputFileName, err)
+	}
+
+	data, err := a.versionInfoDataOf(inputFileName, *outputName, original, rendered, *reformat)
+	if err != nil {
+		return err
+	}
+
+	result := &bumpResult{inputFileName: inputFileName, outputFileName: *outputName, original: original, data: data, before: info, after: rendered}
+	return a.commitResults([]*bumpResult{result}, []error{nil}, nil, commitOptions{
+		sysoFileName: syso.fileName,
+		archs:        archs,
+		backup:       *backup,
+		dryRun:       *dryRun,
+	})
+}
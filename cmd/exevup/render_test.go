@@ -0,0 +1,45 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.0.0")
+	code, _, stderr := runApp("set", "-company", "Example Corp", "-copyright", "Copyright © {{.Year}} {{.CompanyName}}", "-comment", `Built from {{env "EXEVUP_TEST_COMMIT"}}`, "-product-name", "{{.Product}}", fileName)
+	require.Equal(t, exitOK, code, stderr)
+	outputName := filepath.Join(t.TempDir(), "rendered.json")
+
+	t.Run("undefined variable", func(t *testing.T) {
+		code, _, stderr := runApp("render", "-var", "Product=Example", "-o", outputName, fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "EXEVUP_TEST_COMMIT")
+		assert.NoFileExists(t, outputName)
+	})
+
+	t.Run("rendered", func(t *testing.T) {
+		t.Setenv("EXEVUP_TEST_COMMIT", "abc1234")
+
+		code, _, stderr := runApp("render", "-var", "Product=Example", "-date", "2024-03-05", "-o", outputName, fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		info := readVersionInfoFile(t, outputName)
+		assert.Equal(t, "Copyright © 2024 Example Corp", info.StringFileInfo.LegalCopyright)
+		assert.Equal(t, "Built from abc1234", info.StringFileInfo.Comments)
+		assert.Equal(t, "Example", info.StringFileInfo.ProductName)
+		assert.Equal(t, "{{.Product}}", readVersionInfoFile(t, fileName).StringFileInfo.ProductName)
+	})
+
+	t.Run("output is input", func(t *testing.T) {
+		code, _, stderr := runApp("render", "-o", fileName, fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "-output must be given")
+
+		code, _, _ = runApp("render", fileName)
+		assert.Equal(t, exitUsage, code)
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// writtenNotationOf guesses the notation in which versionString was written.
+func writtenNotationOf(versionString string) model.VersionNotation {
+	if strings.ContainsAny(versionString, "-+") {
+		return model.NotationSemVer
+	}
+
+	switch strings.Count(versionString, ".") {
+	case 0, 1:
+		return model.NotationSimple
+	case 2:
+		return model.NotationNormal
+	default:
+		return model.NotationDetail
+	}
+}
+
+// versionSet assigns versionString to target of info. When the version of target is written in SemVer,
+// a plain version such as 1.4.0 is taken as a SemVer release, so that it stays above the pre-releases before it.
+func versionSet(info model.Info, versionString string, target model.VersionTarget, notation model.VersionNotation) (model.Info, error) {
+	return versionSetAs(info, versionString, target, notation, holdsSemVer(info, target))
+}
+
+// holdsSemVer reports whether a version string of target in info is written in SemVer.
+func holdsSemVer(info model.Info, target model.VersionTarget) bool {
+	isSemVer := func(versionString string) bool {
+		if writtenNotationOf(versionString) != model.NotationSemVer {
+			return false
+		}
+		_, err := model.ParseSemVer(versionString)
+		return err == nil
+	}
+	return (target != model.TargetProduct && isSemVer(info.StringFileInfo.FileVersion)) ||
+		(target != model.TargetFile && isSemVer(info.StringFileInfo.ProductVersion))
+}
+
+// versionSetAs is versionSet taking a version which is also a SemVer, such as 1.4.0, as a SemVer when semVer is true,
+// so that its FixedFileInfo follows SemVer.Version in any notation and a release stays above its pre-releases.
+func versionSetAs(info model.Info, versionString string, target model.VersionTarget, notation model.VersionNotation, semVer bool) (model.Info, error) {
+	if notation == "" {
+		notation = writtenNotationOf(versionString)
+	}
+
+	semVerVersion, semVerErr := model.ParseSemVer(versionString)
+	if notation == model.NotationSemVer {
+		if semVerErr != nil {
+			return info, semVerErr
+		}
+		if err := semVerVersion.CheckRange(); err != nil {
+			return info, err
+		}
+		return info.SemVerUpdated(semVerVersion, semVerVersion, target), nil
+	}
+
+	version, err := model.ParseVersion(versionString)
+	//SemVer를 다른 표기법으로 쓸 때는 FixedFileInfo에 들어가는 버전을 그 표기법으로 씀
+	if semVerErr == nil && (err != nil || semVer) {
+		if err = semVerVersion.CheckRange(); err != nil {
+			return info, err
+		}
+		version = semVerVersion.Version()
+	}
+	if err != nil {
+		return info, err
+	}
+	if err = version.CheckRange(); err != nil {
+		return info, err
+	}
+	return info.VersionUpdated(version, version, target, notation), nil
+}
+
+func (a app) set(args []string) error {
+	fs := a.newFlagSet("set", "exevup set [flags] [file]", "Assign an explicit version or StringFileInfo fields to file, which is versioninfo.json by default.")
+
+	versionValue := fs.String("version", "", "version to assign, e.g. 1.2.3.4 or 1.4.0-rc.1")
+	fs.StringVar(versionValue, "v", *versionValue, "alias for -version")
+
+	notationValue := fs.String("notation", "", "notation for version - simple/normal/detail/semver, blank for as written")
+	fs.StringVar(notationValue, "n", *notationValue, "alias for -notation")
+
+	targetValue := fs.String("target", string(model.TargetBoth), "target for versioning - both/file/product")
+	fs.StringVar(targetValue, "t", *targetValue, "alias for -target")
+
+	outputName := fs.String("output", "", "output file name, - for stdout, blank for input itself")
+	fs.StringVar(outputName, "o", *outputName, "alias for -output")
+
+	fields := a.stringFieldFlags(fs)
+
+	syso := a.sysoFlags(fs)
+
+	create := fs.Bool("create", false, "start from the default version info when file does not exist")
+
+	reformat := fs.Bool("reformat", false, "rewrite the whole file instead of only the changed values")
+	backup := fs.Bool("backup", false, "keep the previous content of the output file as .bak")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a unified diff instead of writing any file")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	inputFileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	if err = a.checkStdio(fs, []string{inputFileName}, *outputName); err != nil {
+		return err
+	}
+
+	if *versionValue == "" && !fields.isSet(fs) {
+		return a.usageError(fs, "nothing to set, give -version or fields")
+	}
+
+	target, err := a.targetOf(fs, *targetValue)
+	if err != nil {
+		return err
+	}
+	if *notationValue != "" {
+		if _, err = a.notationOf(fs, *notationValue); err != nil {
+			return err
+		}
+	}
+
+	archs, err := a.archsOf(fs, syso.archs)
+	if err != nil {
+		return err
+	}
+
+	outputFileName := inputFileName
+	if *outputName != "" {
+		outputFileName = *outputName
+	}
+
+	original, before, err := a.readVersionInfo(inputFileName, *create)
+	if err != nil {
+		return err
+	}
+
+	info := before
+	if *versionValue != "" {
+		info, err = versionSet(info, *versionValue, target, model.VersionNotation(*notationValue))
+		if err != nil {
+			return err
+		}
+	}
+
+	if info, err = fields.updated(fs, info); err != nil {
+		return err
+	}
+
+	data, err := a.versionInfoDataOf(inputFileName, outputFileName, original, info, *reformat)
+	if err != nil {
+		return err
+	}
+
+	a.reportOptions(target, model.VersionNotation(*notationValue), *dryRun)
+	result := &bumpResult{inputFileName: inputFileName, outputFileName: outputFileName, original: original, data: data, before: before, after: info}
+	return a.commitResults([]*bumpResult{result}, []error{nil}, nil, commitOptions{
+		sysoFileName: syso.fileName,
+		archs:        archs,
+		target:       target,
+		backup:       *backup,
+		dryRun:       *dryRun,
+	})
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldsSemVer(t *testing.T) {
+	info := model.Info{}
+	info.StringFileInfo.FileVersion = "1.4.0-rc.2"
+	info.StringFileInfo.ProductVersion = "1.4.0"
+
+	assert.True(t, holdsSemVer(info, model.TargetBoth))
+	assert.True(t, holdsSemVer(info, model.TargetFile))
+	assert.False(t, holdsSemVer(info, model.TargetProduct))
+
+	info.StringFileInfo.FileVersion = "1.4.0-rc.02"
+	assert.False(t, holdsSemVer(info, model.TargetFile))
+}
+
+func TestWrittenNotationOf(t *testing.T) {
+	assert.Equal(t, model.NotationSimple, writtenNotationOf("1.2"))
+	assert.Equal(t, model.NotationNormal, writtenNotationOf("1.2.3"))
+	assert.Equal(t, model.NotationDetail, writtenNotationOf("1.2.3.4"))
+	assert.Equal(t, model.NotationSemVer, writtenNotationOf("1.2.3-rc.1"))
+	assert.Equal(t, model.NotationSemVer, writtenNotationOf("1.2.3+sha.abc"))
+}
+
+func TestVersionSet(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		notation      model.VersionNotation
+		expected      string
+		expectedFixed model.Version
+	}{
+		{
+			name:          "as written",
+			version:       "1.2.3",
+			expected:      "1.2.3",
+			expectedFixed: model.Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:          "explicit notation",
+			version:       "1.2.3",
+			notation:      model.NotationDetail,
+			expected:      "1.2.3.0",
+			expectedFixed: model.Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:          "semver",
+			version:       "1.4.0-rc.2+sha.abc",
+			expected:      "1.4.0-rc.2+sha.abc",
+			expectedFixed: model.Version{Major: 1, Minor: 4, Build: 2},
+		},
+		{
+			name:          "semver in another notation",
+			version:       "1.4.0-rc.2",
+			notation:      model.NotationDetail,
+			expected:      "1.4.0.2",
+			expectedFixed: model.Version{Major: 1, Minor: 4, Build: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := versionSet(model.Info{}, tt.version, model.TargetBoth, tt.notation)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, info.StringFileInfo.FileVersion)
+			assert.Equal(t, tt.expected, info.StringFileInfo.ProductVersion)
+			assert.Equal(t, tt.expectedFixed, model.Version(info.FixedFileInfo.FileVersion))
+		})
+	}
+
+	t.Run("release after pre-release", func(t *testing.T) {
+		info, err := versionSet(model.Info{}, "1.4.0-rc.2", model.TargetBoth, "")
+		require.NoError(t, err)
+
+		info, err = versionSet(info, "1.4.0", model.TargetBoth, "")
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 65535}, model.Version(info.FixedFileInfo.FileVersion))
+
+		info, err = versionSet(info, "1.4.1", model.TargetBoth, "")
+		require.NoError(t, err)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Patch: 1}, model.Version(info.FixedFileInfo.FileVersion))
+	})
+
+	_, err := versionSet(model.Info{}, "1.2.x", model.TargetBoth, "")
+	assert.Error(t, err)
+
+	_, err = versionSet(model.Info{}, "1.2.3.65536", model.TargetBoth, "")
+	assert.ErrorIs(t, err, model.ErrVersionOutOfRange)
+}
+
+func TestSet(t *testing.T) {
+	t.Run("to output", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.0.0")
+		outputName := filepath.Join(t.TempDir(), "output.json")
+
+		code, _, stderr := runApp("set", "-v", "2.1.0", "-t", "product", fileName, "-o", outputName)
+		assert.Equal(t, exitOK, code, stderr)
+
+		assert.Equal(t, "1.0.0", readVersionInfoFile(t, fileName).StringFileInfo.ProductVersion)
+		info := readVersionInfoFile(t, outputName)
+		assert.Equal(t, "1.0.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, "2.1.0", info.StringFileInfo.ProductVersion)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.0.0")
+
+		code, _, stderr := runApp("set", "--company", "Example Corp", "--description", "Example tool", "--field", "legalcopyright=Copyright (c) 2024", "--field", "Comments=a=b", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+
+		info := readVersionInfoFile(t, fileName)
+		assert.Equal(t, "1.0.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, "Example Corp", info.StringFileInfo.CompanyName)
+		assert.Equal(t, "Example tool", info.StringFileInfo.FileDescription)
+		assert.Equal(t, "Copyright (c) 2024", info.StringFileInfo.LegalCopyright)
+		assert.Equal(t, "a=b", info.StringFileInfo.Comments)
+	})
+
+	t.Run("clearing a field", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.0.0")
+		code, _, stderr := runApp("set", "-product-name", "Example", fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		code, _, stderr = runApp("set", "-product-name=", "-comment", "kept", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		info := readVersionInfoFile(t, fileName)
+		assert.Equal(t, "", info.StringFileInfo.ProductName)
+		assert.Equal(t, "kept", info.StringFileInfo.Comments)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.0.0")
+
+		code, stdout, stderr := runApp("set", "-dry-run", "-company", "Example Corp", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "+\t\t\"CompanyName\": \"Example Corp\",\n")
+		assert.Equal(t, "", readVersionInfoFile(t, fileName).StringFileInfo.CompanyName)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		code, _, stderr := runApp("set", "-field", "Company=Example", newVersionInfoFile(t, "1.0.0"))
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "unknown StringFileInfo field")
+	})
+
+	t.Run("field without value", func(t *testing.T) {
+		code, _, stderr := runApp("set", "-field", "CompanyName", newVersionInfoFile(t, "1.0.0"))
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "expected Key=Value")
+	})
+
+	t.Run("nothing to set", func(t *testing.T) {
+		code, _, stderr := runApp("set", newVersionInfoFile(t, "1.0.0"))
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "nothing to set")
+	})
+}
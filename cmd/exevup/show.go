@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func writeInfo(w io.Writer, info model.Info) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fixed := info.FixedFileInfo
+	fmt.Fprintf(tw, "FixedFileInfo\n")
+	fmt.Fprintf(tw, "  FileVersion\t%s\n", model.Version(fixed.FileVersion).String(model.NotationDetail))
+	fmt.Fprintf(tw, "  ProductVersion\t%s\n", model.Version(fixed.ProductVersion).String(model.NotationDetail))
+	fmt.Fprintf(tw, "  FileFlagsMask\t%s\n", fixed.FileFlagsMask)
+	fmt.Fprintf(tw, "  FileFlags\t%s\n", fixed.FileFlags)
+	fmt.Fprintf(tw, "  FileOS\t%s\n", fixed.FileOS)
+	fmt.Fprintf(tw, "  FileType\t%s\n", fixed.FileType)
+	fmt.Fprintf(tw, "  FileSubType\t%s\n", fixed.FileSubType)
+
+	fmt.Fprintf(tw, "StringFileInfo\n")
+	for _, field := range model.StringFields {
+		fmt.Fprintf(tw, "  %s\t%s\n", field, info.StringField(field))
+	}
+
+	translation := info.VarFileInfo.Translation
+	fmt.Fprintf(tw, "VarFileInfo\n")
+	fmt.Fprintf(tw, "  Translation\t%04X %04X\n", uint16(translation.LangID), uint16(translation.CharsetID))
+
+	fmt.Fprintf(tw, "IconPath\t%s\n", info.IconPath)
+	fmt.Fprintf(tw, "ManifestPath\t%s\n", info.ManifestPath)
+
+	return tw.Flush()
+}
+
+func (a app) show(args []string) error {
+	fs := a.newFlagSet("show", "exevup show [flags] [file]", "Print all fields of file, which is versioninfo.json by default.")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+
+	info, err := a.parseVersionInfoFromInput(fileName)
+	if err != nil {
+		return err
+	}
+
+	a.reportFile(fileReport{Input: fileName, Info: infoReportOf(info)})
+	return writeInfo(a.stdout, info)
+}
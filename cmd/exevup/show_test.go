@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShow(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+
+	code, stdout, stderr := runApp("show", fileName)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Regexp(t, `FixedFileInfo\n  FileVersion +1\.2\.3\.0\n`, stdout)
+	assert.Regexp(t, `\n  ProductVersion +1\.2\.3\n`, stdout)
+	assert.Contains(t, stdout, "  Translation  0000 0000\n")
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/simp7/goversioninfo-toolkit/workspace"
+)
+
+// syncedInfo returns info with the canonical version and the overrides of file applied.
+// A canonical version which is also a SemVer is taken as one, so that no file goes backwards from a pre-release to its release.
+func syncedInfo(info model.Info, w workspace.Workspace, file workspace.File, version string) (model.Info, error) {
+	info, err := versionSetAs(info, version, model.TargetBoth, w.Notation(file), true)
+	if err != nil {
+		return info, err
+	}
+
+	for key, value := range file.Fields {
+		field, err := model.ParseStringField(key)
+		if err != nil {
+			return info, err
+		}
+		if info, err = info.StringFieldUpdated(field, value); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+// prepareSync returns the content of every file of w following version.
+func (a app) prepareSync(w workspace.Workspace, version string, reformat bool) ([]*bumpResult, []error) {
+	files := make(map[string]workspace.File, len(w.Config.Files))
+	fileNames := make([]string, 0, len(w.Config.Files))
+	for _, file := range w.Config.Files {
+		files[w.Path(file)] = file
+		fileNames = append(fileNames, w.Path(file))
+	}
+
+	return forEachFile(fileNames, func(fileName string) (result *bumpResult, err error) {
+		result = &bumpResult{inputFileName: fileName, outputFileName: fileName}
+		if result.original, result.before, err = a.readVersionInfo(fileName, false); err != nil {
+			return
+		}
+		if result.after, err = syncedInfo(result.before, w, files[fileName], version); err != nil {
+			return
+		}
+		result.data, err = a.versionInfoDataOf(fileName, fileName, result.original, result.after, reformat)
+		return
+	})
+}
+
+// bumpWorkspace bumps the canonical version of the workspace config once and syncs every file to it.
+// The canonical version keeps the notation in which it is written unless notationSet.
+func (a app) bumpWorkspace(fileName string, options bumpOptions, notationSet bool, reformat bool, commit commitOptions) error {
+	w, err := workspace.Load(fileName)
+	if err != nil {
+		return err
+	}
+
+	if !notationSet {
+		options.notation = writtenNotationOf(w.Config.Version)
+	}
+	options.target = model.TargetBoth
+	options.dir = w.Dir()
+
+	canonical, err := versionSet(model.Info{}, w.Config.Version, model.TargetBoth, options.notation)
+	if err != nil {
+		return err
+	}
+
+	if options, err = options.autoLevelResolved(a.stderr); err != nil {
+		return err
+	}
+	options = options.commitResolved()
+
+	bumped, err := bumpedInfo(canonical, options)
+	if err != nil {
+		return err
+	}
+	version := bumped.StringFileInfo.FileVersion
+
+	config, err := w.VersionUpdated(version)
+	if err != nil {
+		return err
+	}
+
+	configReport := fileReport{
+		Input:  w.FileName,
+		Before: &versionReport{File: w.Config.Version, Product: w.Config.Version},
+		After:  &versionReport{File: version, Product: version},
+	}
+	if commit.dryRun {
+		configReport.Diff = unifiedDiff(w.FileName, w.FileName, w.Data(), config)
+		fmt.Fprint(a.stdout, configReport.Diff)
+	}
+
+	results, errs := a.prepareSync(w, version, reformat)
+	err = a.commitResults(results, errs, []pendingWrite{{fileName: w.FileName, data: config}}, commit)
+	if err == nil && !commit.dryRun {
+		configReport.Written = []string{w.FileName}
+	}
+	a.reportFile(configReport)
+	if err != nil || commit.dryRun {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "%s: %s -> %s\n", w.FileName, w.Config.Version, version)
+	return nil
+}
+
+func (a app) sync(args []string) error {
+	fs := a.newFlagSet("sync", "exevup sync [flags]", "Set the canonical version of the workspace config, which is "+workspace.FileName+" by default,\n"+
+		"to every file it lists, with the notation and fields given for each file.\n"+
+		"With -check, exit code is 3 when any file is out of sync.")
+
+	workspaceName := fs.String("workspace", workspace.FileName, "workspace config")
+	fs.StringVar(workspaceName, "w", *workspaceName, "alias for -workspace")
+
+	check := fs.Bool("check", false, "report files which are out of sync instead of writing them")
+	reformat := fs.Bool("reformat", false, "rewrite the whole file instead of only the changed values")
+	backup := fs.Bool("backup", false, "keep the previous content of each file as .bak")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a unified diff instead of writing any file")
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return a.usageError(fs, "files are listed in the workspace config, not given as arguments")
+	}
+
+	w, err := workspace.Load(*workspaceName)
+	if err != nil {
+		return err
+	}
+
+	results, errs := a.prepareSync(w, w.Config.Version, *reformat)
+	if !*check {
+		return a.commitResults(results, errs, nil, commitOptions{target: model.TargetBoth, batch: true, backup: *backup, dryRun: *dryRun})
+	}
+
+	outOfSync := 0
+	for i, result := range results {
+		switch {
+		case errs[i] != nil:
+			return fmt.Errorf("%s: %w", result.inputFileName, errs[i])
+		case !bytes.Equal(result.original, result.data):
+			outOfSync++
+			a.reportFile(result.report(nil))
+			fmt.Fprintf(a.stdout, "%s: out of sync, %s\n", result.inputFileName, versionChangeOf(result.before, result.after, model.TargetBoth))
+		}
+	}
+	if outOfSync > 0 {
+		return exitError{code: exitInvalid, err: fmt.Errorf("%d of %d files are out of sync with %s", outOfSync, len(results), w.FileName)}
+	}
+	return nil
+}
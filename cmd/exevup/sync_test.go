@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/simp7/goversioninfo-toolkit/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWorkspace creates a workspace with the given config and a version info file at 0.1.0 for client and server.
+func newWorkspace(t *testing.T, config string) (configName string, client string, server string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	configName = filepath.Join(dir, workspace.FileName)
+	require.NoError(t, os.WriteFile(configName, []byte(config), 0644))
+
+	client = filepath.Join(dir, "cmd", "client", "versioninfo.json")
+	server = filepath.Join(dir, "cmd", "server", "versioninfo.json")
+	for _, fileName := range []string{client, server} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+		require.NoError(t, os.WriteFile(fileName, []byte(`{"StringFileInfo": {"FileVersion": "0.1.0", "ProductVersion": "0.1.0"}}`), 0644))
+	}
+	return
+}
+
+const workspaceConfig = `# products shipped together
+version: 1.4.0
+files:
+  - path: cmd/client/versioninfo.json
+  - path: cmd/server/versioninfo.json
+    notation: detail
+    fields:
+      ProductName: Example Server
+`
+
+func TestSync(t *testing.T) {
+	t.Run("sync", func(t *testing.T) {
+		configName, client, server := newWorkspace(t, workspaceConfig)
+
+		code, stdout, stderr := runApp("sync", "-w", configName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, client+": 0.1.0 -> 1.4.0\n"+server+": 0.1.0 -> 1.4.0.65535\n", stdout)
+
+		assert.Equal(t, "1.4.0", readVersionInfoFile(t, client).StringFileInfo.ProductVersion)
+		info := readVersionInfoFile(t, server)
+		assert.Equal(t, "1.4.0.65535", info.StringFileInfo.FileVersion)
+		assert.Equal(t, "Example Server", info.StringFileInfo.ProductName)
+
+		code, _, stderr = runApp("sync", "-check", "-w", configName)
+		assert.Equal(t, exitOK, code, stderr)
+	})
+
+	t.Run("semver with notation override", func(t *testing.T) {
+		configName, client, server := newWorkspace(t, strings.Replace(workspaceConfig, "version: 1.4.0", "version: 1.4.0-rc.1", 1))
+
+		code, _, stderr := runApp("sync", "-w", configName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.4.0-rc.1", readVersionInfoFile(t, client).StringFileInfo.FileVersion)
+		info := readVersionInfoFile(t, server)
+		assert.Equal(t, "1.4.0.1", info.StringFileInfo.FileVersion)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 1}, model.Version(info.FixedFileInfo.FileVersion))
+	})
+
+	t.Run("pre-release to release", func(t *testing.T) {
+		configName, client, server := newWorkspace(t, strings.Replace(workspaceConfig, "version: 1.4.0", "version: 1.4.0-rc.2", 1))
+
+		code, _, stderr := runApp("sync", "-w", configName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 2}, model.Version(readVersionInfoFile(t, client).FixedFileInfo.FileVersion))
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 2}, model.Version(readVersionInfoFile(t, server).FixedFileInfo.FileVersion))
+
+		code, _, stderr = runApp("bump", "-n", "semver", "-l", "release", "-w", configName)
+		require.Equal(t, exitOK, code, stderr)
+
+		info := readVersionInfoFile(t, client)
+		assert.Equal(t, "1.4.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 65535}, model.Version(info.FixedFileInfo.FileVersion))
+		info = readVersionInfoFile(t, server)
+		assert.Equal(t, "1.4.0.65535", info.StringFileInfo.FileVersion)
+		assert.Equal(t, model.Version{Major: 1, Minor: 4, Build: 65535}, model.Version(info.FixedFileInfo.FileVersion))
+	})
+
+	t.Run("check", func(t *testing.T) {
+		configName, client, _ := newWorkspace(t, workspaceConfig)
+		original, err := os.ReadFile(client)
+		require.NoError(t, err)
+
+		code, stdout, stderr := runApp("sync", "-check", "-w", configName)
+		assert.Equal(t, exitInvalid, code)
+		assert.Contains(t, stdout, client+": out of sync, 0.1.0 -> 1.4.0\n")
+		assert.Contains(t, stderr, "2 of 2 files are out of sync")
+
+		data, err := os.ReadFile(client)
+		require.NoError(t, err)
+		assert.Equal(t, string(original), string(data))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		configName, _, server := newWorkspace(t, workspaceConfig)
+		require.NoError(t, os.Remove(server))
+
+		code, _, stderr := runApp("sync", "-w", configName)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "file not found")
+	})
+
+	t.Run("arguments", func(t *testing.T) {
+		code, _, stderr := runApp("sync", "versioninfo.json")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "listed in the workspace config")
+	})
+}
+
+func TestBumpWorkspace(t *testing.T) {
+	configName, client, server := newWorkspace(t, workspaceConfig)
+
+	code, stdout, stderr := runApp("bump", "-l", "minor", "-w", configName)
+	require.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, configName+": 1.4.0 -> 1.5.0\n")
+
+	data, err := os.ReadFile(configName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# products shipped together\nversion: 1.5.0\n")
+	assert.Equal(t, "1.5.0", readVersionInfoFile(t, client).StringFileInfo.FileVersion)
+	assert.Equal(t, "1.5.0.65535", readVersionInfoFile(t, server).StringFileInfo.FileVersion)
+
+	t.Run("default config in working directory", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(filepath.Dir(configName)))
+		t.Cleanup(func() { os.Chdir(wd) })
+
+		code, _, stderr := runApp("-l", "patch")
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, "1.5.1", readVersionInfoFile(t, client).StringFileInfo.FileVersion)
+	})
+
+	t.Run("template notation", func(t *testing.T) {
+		original, err := os.ReadFile(configName)
+		require.NoError(t, err)
+
+		code, _, stderr := runApp("bump", "-n", "template:{{.Major}}.{{.Minor}}.{{.Patch}} (build {{.Build}})", "-w", configName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "template notations can not be used with a workspace")
+
+		data, err := os.ReadFile(configName)
+		require.NoError(t, err)
+		assert.Equal(t, string(original), string(data))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		code, stdout, stderr := runApp("bump", "-dry-run", "-w", configName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "-version: 1.5.1\n+version: 1.5.2\n")
+		assert.Equal(t, "1.5.1", readVersionInfoFile(t, client).StringFileInfo.FileVersion)
+	})
+}
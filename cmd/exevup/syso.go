@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+type sysoOptions struct {
+	fileName string
+	archs    string
+}
+
+func (a app) sysoFlags(fs *flag.FlagSet) *sysoOptions {
+	options := &sysoOptions{}
+	fs.StringVar(&options.fileName, "syso", "", "syso file to generate with the version info, blank for none, e.g. resource.syso")
+	fs.StringVar(&options.archs, "arch", "amd64", "comma separated architectures for -syso - 386/amd64/arm/arm64, or all")
+	return options
+}
+
+func (a app) archsOf(fs *flag.FlagSet, value string) ([]string, error) {
+	if value == "all" {
+		return model.Archs, nil
+	}
+
+	archs := strings.Split(value, ",")
+	for _, arch := range archs {
+		if !slices.Contains(model.Archs, arch) {
+			return nil, a.usageError(fs, "unknown architecture %q", arch)
+		}
+	}
+	return archs, nil
+}
+
+// sysoWrites renders a syso file per architecture to be written with the version info files. When more than one
+// architecture is given, each file is named resource_windows_{arch}.syso next to the -syso path.
+// Relative icon and manifest paths are resolved against dir.
+func sysoWrites(info model.Info, dir string, fileName string, archs []string) (writes []pendingWrite, err error) {
+	if fileName == "" {
+		return nil, nil
+	}
+
+	info = info.ResolvePaths(dir)
+	for _, arch := range archs {
+		sysoFileName := fileName
+		if len(archs) > 1 {
+			sysoFileName = model.SysoFileName(fileName, arch)
+		}
+
+		data, err := info.SysoData(arch)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, pendingWrite{fileName: sysoFileName, data: data, noBackup: true})
+	}
+	return writes, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/peversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryNamesOf(t *testing.T, dir string) (names []string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestBumpWithSyso(t *testing.T) {
+	t.Run("single architecture", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+		sysoFileName := filepath.Join(filepath.Dir(fileName), "resource.syso")
+
+		code, _, stderr := runApp("bump", "-l", "minor", "-syso", sysoFileName, fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		info, err := peversion.Open(sysoFileName)
+		require.NoError(t, err)
+		assert.Equal(t, "1.3.0", info.StringFileInfo.FileVersion)
+		assert.Equal(t, 3, info.FixedFileInfo.FileVersion.Minor)
+	})
+
+	t.Run("all architectures", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+		dir := filepath.Dir(fileName)
+
+		code, _, stderr := runApp("bump", "-syso", filepath.Join(dir, "resource.syso"), "-arch", "all", fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		for _, arch := range []string{"386", "amd64", "arm", "arm64"} {
+			info, err := peversion.Open(filepath.Join(dir, "resource_windows_"+arch+".syso"))
+			require.NoError(t, err, arch)
+			assert.Equal(t, "1.2.4", info.StringFileInfo.FileVersion)
+		}
+		_, err := os.Stat(filepath.Join(dir, "resource.syso"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("unknown architecture", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+
+		code, _, stderr := runApp("bump", "-syso", "resource.syso", "-arch", "amd64,mips", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, `unknown architecture "mips"`)
+		assert.Equal(t, "1.2.3", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("missing icon leaves json untouched", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+		info := readVersionInfoFile(t, fileName)
+		info.IconPath = "missing.ico"
+		writeVersionInfoFile(t, fileName, info)
+
+		code, _, _ := runApp("bump", "-syso", filepath.Join(filepath.Dir(fileName), "resource.syso"), fileName)
+		assert.Equal(t, exitFailure, code)
+		assert.Equal(t, "1.2.3", readVersionInfoFile(t, fileName).StringFileInfo.FileVersion)
+	})
+
+	t.Run("failed output leaves no syso", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+		dir := filepath.Dir(fileName)
+		sysoFileName := filepath.Join(dir, "resource.syso")
+
+		code, _, _ := runApp("bump", "-syso", sysoFileName, "-o", filepath.Join(dir, "missing", "versioninfo.json"), fileName)
+		assert.Equal(t, exitFailure, code)
+		_, err := os.Stat(sysoFileName)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Equal(t, []string{"versioninfo.json"}, entryNamesOf(t, dir))
+	})
+
+	t.Run("backup skips syso", func(t *testing.T) {
+		fileName := newVersionInfoFile(t, "1.2.3")
+		dir := filepath.Dir(fileName)
+		sysoFileName := filepath.Join(dir, "resource.syso")
+		require.NoError(t, os.WriteFile(sysoFileName, []byte("old"), 0644))
+
+		code, _, stderr := runApp("bump", "-syso", sysoFileName, "-backup", fileName)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, []string{"resource.syso", "versioninfo.json", "versioninfo.json.bak"}, entryNamesOf(t, dir))
+	})
+}
+
+func TestSetWithSyso(t *testing.T) {
+	fileName := newVersionInfoFile(t, "1.2.3")
+	sysoFileName := filepath.Join(filepath.Dir(fileName), "resource.syso")
+
+	code, _, stderr := runApp("set", "-v", "2.0.0.7", "-syso", sysoFileName, "-arch", "386", fileName)
+	require.Equal(t, exitOK, code, stderr)
+
+	info, err := peversion.Open(sysoFileName)
+	require.NoError(t, err)
+	assert.Equal(t, 7, info.FixedFileInfo.ProductVersion.Build)
+}
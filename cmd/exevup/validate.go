@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+func (a app) writeValidateResult(fileName string, issues []model.Issue) error {
+	if len(issues) == 0 {
+		_, err := fmt.Fprintf(a.stdout, "%s: ok\n", fileName)
+		return err
+	}
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(a.stdout, "%s: %s\n", fileName, issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a app) validate(args []string) error {
+	fs := a.newFlagSet("validate", "exevup validate [flags] [file]", "Check file, which is versioninfo.json by default, for problems.\nExit code is 3 when an error, or any issue with -strict, is found.")
+
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+
+	notationValue := fs.String("notation", "", "notation in which the version strings are written, needed for template:{text/template}, blank for plain versions and semver")
+	fs.StringVar(notationValue, "n", *notationValue, "alias for -notation")
+
+	a.formatFlag(fs)
+
+	positional, err := a.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := a.fileNameOf(fs, positional)
+	if err != nil {
+		return err
+	}
+	var notation model.VersionNotation
+	if *notationValue != "" {
+		if notation, err = a.notationOf(fs, *notationValue); err != nil {
+			return err
+		}
+		if _, err = notation.Template(); notation.IsTemplate() && err != nil {
+			return a.usageError(fs, "%s", err)
+		}
+	}
+
+	info, err := a.parseVersionInfoFromInput(fileName)
+	var parseErr model.ParseError
+	if errors.As(err, &parseErr) {
+		return exitError{code: exitInvalid, err: err}
+	}
+	if err != nil {
+		return err
+	}
+
+	issues := model.ValidateAs(info.ResolvePaths(filepath.Dir(fileName)), notation)
+	valid := !model.HasError(issues) && !(*strict && len(issues) > 0)
+	a.reportFile(fileReport{Input: fileName, Valid: &valid, Issues: issues})
+
+	if err = a.writeValidateResult(fileName, issues); err != nil {
+		return err
+	}
+
+	if !valid {
+		return model.ValidationError{FileName: fileName, Issues: issues}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidVersionInfoFile(t *testing.T) string {
+	t.Helper()
+
+	fileName := newVersionInfoFile(t, "1.2.3")
+	code, _, stderr := runApp("set", fileName,
+		"-company", "Example Corp",
+		"-description", "Example tool",
+		"-internal-name", "example",
+		"-original-name", "example.exe",
+		"-product-name", "Example",
+	)
+	require.Equal(t, exitOK, code, stderr)
+
+	info := readVersionInfoFile(t, fileName)
+	info.VarFileInfo = model.DefaultInfo().VarFileInfo
+	writeVersionInfoFile(t, fileName, info)
+	return fileName
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		fileName := newValidVersionInfoFile(t)
+
+		code, stdout, stderr := runApp("validate", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, fileName+": ok\n", stdout)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+		require.NoError(t, os.WriteFile(fileName, []byte(`{"StringFileInfo": {"FileVersion": "1.x"}}`), 0644))
+
+		code, stdout, stderr := runApp("validate", fileName)
+		assert.Equal(t, exitInvalid, code)
+		assert.Contains(t, stdout, "error invalid-version StringFileInfo.FileVersion")
+		assert.Contains(t, stdout, "error missing-required-field StringFileInfo.CompanyName")
+		assert.Contains(t, stderr, "issues found")
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		fileName := newValidVersionInfoFile(t)
+		code, _, stderr := runApp("set", fileName, "-original-name", "example")
+		require.Equal(t, exitOK, code, stderr)
+
+		code, stdout, stderr := runApp("validate", fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Contains(t, stdout, "warning missing-file-extension")
+
+		code, _, _ = runApp("validate", "-strict", fileName)
+		assert.Equal(t, exitInvalid, code)
+	})
+
+	t.Run("paths relative to file", func(t *testing.T) {
+		fileName := newValidVersionInfoFile(t)
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(fileName), "icon.ico"), []byte{}, 0644))
+
+		info := readVersionInfoFile(t, fileName)
+		info.IconPath = "icon.ico"
+		info.ManifestPath = "missing.manifest"
+		writeVersionInfoFile(t, fileName, info)
+
+		code, stdout, _ := runApp("validate", fileName)
+		assert.Equal(t, exitInvalid, code)
+		assert.NotContains(t, stdout, string(model.IssueMissingIcon))
+		assert.Contains(t, stdout, string(model.IssueMissingManifest))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		fileName := newValidVersionInfoFile(t)
+		code, _, stderr := runApp("set", fileName, "-field", "FileVersion=1.2.4")
+		require.Equal(t, exitOK, code, stderr)
+
+		code, stdout, _ := runApp("validate", "-output-format", "json", fileName)
+		assert.Equal(t, exitInvalid, code)
+
+		var result report
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+		assert.False(t, result.OK)
+		assert.Equal(t, errorInvalid, result.Error.Code)
+		require.Len(t, result.Files, 1)
+		file := result.Files[0]
+		assert.Equal(t, fileName, file.Input)
+		assert.False(t, *file.Valid)
+		require.Len(t, file.Issues, 1)
+		assert.Equal(t, model.IssueVersionMismatch, file.Issues[0].Code)
+		assert.Equal(t, model.SeverityError, file.Issues[0].Severity)
+	})
+
+	t.Run("template notation", func(t *testing.T) {
+		fileName := newValidVersionInfoFile(t)
+		notation := "template:{{.Major}}.{{.Minor}}.{{.Patch}} (build {{.Build}})"
+		code, _, stderr := runApp("bump", "-l", "build", "-n", notation, fileName)
+		require.Equal(t, exitOK, code, stderr)
+
+		code, stdout, _ := runApp("validate", "-strict", fileName)
+		assert.Equal(t, exitInvalid, code)
+		assert.Contains(t, stdout, string(model.IssueInvalidVersion))
+
+		code, stdout, stderr = runApp("validate", "-strict", "-n", notation, fileName)
+		assert.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, fileName+": ok\n", stdout)
+
+		code, _, stderr = runApp("validate", "-n", "template:{{.Major", fileName)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "invalid version template")
+	})
+
+	t.Run("unknown output format", func(t *testing.T) {
+		code, _, stderr := runApp("validate", "-output-format", "xml")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "unknown output format")
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "versioninfo.json")
+		require.NoError(t, os.WriteFile(fileName, []byte("{invalid json"), 0644))
+
+		code, _, _ := runApp("validate", fileName)
+		assert.Equal(t, exitInvalid, code)
+	})
+}
@@ -0,0 +1,109 @@
+// Package conventional chooses the level of the next version from commit messages
+// following the Conventional Commits specification (https://www.conventionalcommits.org).
+package conventional
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+var (
+	ErrNotConventional = errors.New("not a conventional commit")
+)
+
+var (
+	headerPattern   = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*)(?:\(([^()\r\n]*)\))?(!)?: (.+)$`)
+	breakingPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+type Commit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// Parse reads the header and the footers of message. Type is lowercased, as the specification treats it case-insensitively.
+func Parse(message string) (result Commit, err error) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	matches := headerPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if matches == nil {
+		err = ErrNotConventional
+		return
+	}
+
+	result.Type = strings.ToLower(matches[1])
+	result.Scope = matches[2]
+	result.Breaking = matches[3] == "!" || breakingPattern.MatchString(body)
+	result.Description = matches[4]
+	return
+}
+
+// Level returns the level which the commit requires, or an empty level when it does not affect the version.
+func (c Commit) Level() model.VersionLevel {
+	switch {
+	case c.Breaking:
+		return model.LevelMajor
+	case c.Type == "feat":
+		return model.LevelMinor
+	case c.Type == "fix":
+		return model.LevelPatch
+	}
+	return ""
+}
+
+func (c Commit) String() string {
+	result := c.Type
+	if c.Scope != "" {
+		result += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		result += "!"
+	}
+	return result + ": " + c.Description
+}
+
+// Reason is a commit which affects the version, with the level it requires.
+type Reason struct {
+	Commit Commit
+	Level  model.VersionLevel
+}
+
+func rank(level model.VersionLevel) int {
+	switch level {
+	case model.LevelMajor:
+		return 3
+	case model.LevelMinor:
+		return 2
+	case model.LevelPatch:
+		return 1
+	}
+	return 0
+}
+
+// Decide returns the highest level required by messages and the commits which require a level, in the given order.
+// When no commit requires a level, such as when there are only docs or chore commits, LevelBuild is returned.
+// Messages which do not follow the specification are ignored.
+func Decide(messages []string) (level model.VersionLevel, reasons []Reason) {
+	level = model.LevelBuild
+	for _, message := range messages {
+		commit, err := Parse(message)
+		if err != nil {
+			continue
+		}
+
+		commitLevel := commit.Level()
+		if commitLevel == "" {
+			continue
+		}
+
+		reasons = append(reasons, Reason{Commit: commit, Level: commitLevel})
+		if rank(commitLevel) > rank(level) {
+			level = commitLevel
+		}
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected Commit
+		hasError bool
+	}{
+		{
+			name:     "feature",
+			message:  "feat: add calver scheme",
+			expected: Commit{Type: "feat", Description: "add calver scheme"},
+		},
+		{
+			name:     "scope",
+			message:  "fix(patch): keep indentation",
+			expected: Commit{Type: "fix", Scope: "patch", Description: "keep indentation"},
+		},
+		{
+			name:     "breaking with bang",
+			message:  "refactor(model)!: rename Info",
+			expected: Commit{Type: "refactor", Scope: "model", Description: "rename Info", Breaking: true},
+		},
+		{
+			name:     "breaking footer",
+			message:  "feat: drop go 1.20\n\nBREAKING CHANGE: go 1.21 is required",
+			expected: Commit{Type: "feat", Description: "drop go 1.20", Breaking: true},
+		},
+		{
+			name:     "breaking footer with hyphen",
+			message:  "fix: x\n\nReviewed-by: someone\nBREAKING-CHANGE: y",
+			expected: Commit{Type: "fix", Description: "x", Breaking: true},
+		},
+		{
+			name:     "breaking change in lowercase is not a footer",
+			message:  "fix: x\n\nno breaking change: really",
+			expected: Commit{Type: "fix", Description: "x"},
+		},
+		{
+			name:     "type is case-insensitive",
+			message:  "FEAT: shout",
+			expected: Commit{Type: "feat", Description: "shout"},
+		},
+		{
+			name:     "missing space",
+			message:  "feat:add",
+			hasError: true,
+		},
+		{
+			name:     "plain message",
+			message:  "Merge branch 'release'",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.message)
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrNotConventional)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCommitString(t *testing.T) {
+	assert.Equal(t, "feat(cli)!: remove flag", Commit{Type: "feat", Scope: "cli", Description: "remove flag", Breaking: true}.String())
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		expected model.VersionLevel
+		reasons  int
+	}{
+		{name: "no commits", expected: model.LevelBuild},
+		{name: "only chores", messages: []string{"chore: deps", "docs: readme", "Merge branch 'x'"}, expected: model.LevelBuild},
+		{name: "fix", messages: []string{"docs: readme", "fix: crash"}, expected: model.LevelPatch, reasons: 1},
+		{name: "feature wins over fix", messages: []string{"fix: crash", "feat: flag", "fix: typo"}, expected: model.LevelMinor, reasons: 3},
+		{name: "breaking wins", messages: []string{"feat: flag", "chore!: drop support"}, expected: model.LevelMajor, reasons: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, reasons := Decide(tt.messages)
+			assert.Equal(t, tt.expected, level)
+			assert.Len(t, reasons, tt.reasons)
+		})
+	}
+}
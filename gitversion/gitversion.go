@@ -0,0 +1,123 @@
+// Package gitversion derives a version from the release tags of a git repository, similar to `git describe`.
+// It runs the local git binary, so it works offline on any clone, but the commit count of a shallow clone
+// is only as deep as its history.
+package gitversion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+var (
+	ErrNoTag = errors.New("no release tag")
+)
+
+// Description is the latest release tag reachable from HEAD and the number of commits since it.
+type Description struct {
+	Tag     string
+	Version model.Version
+	Commits int
+}
+
+// Next returns the version of the tag with the number of commits since it added to build,
+// so that a tag in detail notation such as v1.2.3.4 is never followed by a lower version.
+func (d Description) Next() model.Version {
+	result := d.Version
+	result.Build += d.Commits
+	return result
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// versionOfTag returns the version of tag, which is prefix followed by a version in simple, normal or detail notation.
+// Pre-release tags such as v1.4.0-rc.1 are not release tags, so they are not accepted.
+func versionOfTag(tag string, prefix string) (model.Version, bool) {
+	versionString, ok := strings.CutPrefix(tag, prefix)
+	if !ok || versionString == "" {
+		return model.Version{}, false
+	}
+
+	version, err := model.ParseVersion(versionString)
+	if err != nil {
+		return model.Version{}, false
+	}
+	return version, true
+}
+
+// Describe finds the highest release tag merged into HEAD of the repository containing dir,
+// so that a tag on another branch which was merged later is found as well as the nearest one.
+func Describe(dir string, prefix string) (result Description, err error) {
+	tags, err := git(dir, "tag", "--merged", "HEAD", "--list", prefix+"*")
+	if err != nil {
+		return
+	}
+
+	found := false
+	for _, tag := range strings.Fields(tags) {
+		version, ok := versionOfTag(tag, prefix)
+		if ok && (!found || version.Compare(result.Version) > 0) {
+			result.Tag, result.Version, found = tag, version, true
+		}
+	}
+	if !found {
+		err = fmt.Errorf("%w with prefix %q in %s", ErrNoTag, prefix, dir)
+		return
+	}
+
+	count, err := git(dir, "rev-list", "--count", "refs/tags/"+result.Tag+"..HEAD")
+	if err != nil {
+		return
+	}
+	result.Commits, err = strconv.Atoi(count)
+	return
+}
+
+// Messages returns the commit messages since the latest release tag merged into HEAD, newest first.
+// When there is no release tag yet, all commits of HEAD are returned.
+func Messages(dir string, prefix string) ([]string, error) {
+	revisions := "HEAD"
+	description, err := Describe(dir, prefix)
+	switch {
+	case err == nil:
+		revisions = "refs/tags/" + description.Tag + "..HEAD"
+	case !errors.Is(err, ErrNoTag):
+		return nil, err
+	}
+
+	log, err := git(dir, "log", "--format=%B%x00", revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, message := range strings.Split(log, "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+// Commit returns the abbreviated hash of HEAD of the repository containing dir.
+func Commit(dir string) (string, error) {
+	return git(dir, "rev-parse", "--short", "HEAD")
+}
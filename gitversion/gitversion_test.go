@@ -0,0 +1,169 @@
+package gitversion
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repository struct {
+	t   *testing.T
+	dir string
+}
+
+func newRepository(t *testing.T) repository {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	r := repository{t: t, dir: t.TempDir()}
+	r.run("init", "-q", "-b", "main")
+	return r
+}
+
+func (r repository) run(args ...string) {
+	r.t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-C", r.dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+	output, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(output))
+}
+
+func (r repository) commit(message string) {
+	r.t.Helper()
+
+	require.NoError(r.t, os.WriteFile(filepath.Join(r.dir, "file.txt"), []byte(message), 0644))
+	r.run("add", "file.txt")
+	r.run("commit", "-q", "-m", message)
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("commits since tag", func(t *testing.T) {
+		r := newRepository(t)
+		r.commit("first")
+		r.run("tag", "v1.2.0")
+		r.commit("second")
+		r.commit("third")
+
+		result, err := Describe(r.dir, "v")
+		require.NoError(t, err)
+		assert.Equal(t, Description{Tag: "v1.2.0", Version: model.Version{Major: 1, Minor: 2}, Commits: 2}, result)
+		assert.Equal(t, model.Version{Major: 1, Minor: 2, Build: 2}, result.Next())
+	})
+
+	t.Run("tag in detail notation", func(t *testing.T) {
+		r := newRepository(t)
+		r.commit("first")
+		r.run("tag", "v1.2.3.4")
+		r.commit("second")
+		r.commit("third")
+
+		result, err := Describe(r.dir, "v")
+		require.NoError(t, err)
+		assert.Equal(t, model.Version{Major: 1, Minor: 2, Patch: 3, Build: 6}, result.Next())
+	})
+
+	t.Run("highest merged tag", func(t *testing.T) {
+		r := newRepository(t)
+		r.commit("first")
+		r.run("tag", "v1.9.0")
+		r.run("checkout", "-q", "-b", "release")
+		r.commit("release")
+		r.run("tag", "v1.10.0")
+		r.run("tag", "v2.0.0-rc.1")
+		r.run("checkout", "-q", "main")
+		r.commit("main")
+		r.run("merge", "-q", "--no-edit", "-X", "theirs", "release")
+
+		result, err := Describe(r.dir, "v")
+		require.NoError(t, err)
+		assert.Equal(t, "v1.10.0", result.Tag)
+		assert.Equal(t, 2, result.Commits)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		r := newRepository(t)
+		r.commit("first")
+		r.run("tag", "app/v3.1")
+		r.run("tag", "v9.0.0")
+
+		result, err := Describe(r.dir, "app/v")
+		require.NoError(t, err)
+		assert.Equal(t, model.Version{Major: 3, Minor: 1}, result.Version)
+		assert.Equal(t, 0, result.Commits)
+	})
+
+	t.Run("no tag", func(t *testing.T) {
+		r := newRepository(t)
+		r.commit("first")
+		r.run("tag", "latest")
+
+		_, err := Describe(r.dir, "v")
+		assert.ErrorIs(t, err, ErrNoTag)
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not installed")
+		}
+
+		_, err := Describe(t.TempDir(), "v")
+		assert.Error(t, err)
+	})
+}
+
+func TestMessages(t *testing.T) {
+	r := newRepository(t)
+	r.commit("feat: first")
+
+	messages, err := Messages(r.dir, "v")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: first"}, messages)
+
+	r.run("tag", "v1.0.0")
+	r.commit("fix: second\n\nwith body")
+	r.commit("docs: third")
+
+	messages, err = Messages(r.dir, "v")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs: third", "fix: second\n\nwith body"}, messages)
+}
+
+func TestCommit(t *testing.T) {
+	r := newRepository(t)
+	_, err := Commit(r.dir)
+	assert.Error(t, err)
+
+	r.commit("feat: first")
+	commit, err := Commit(r.dir)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{7,}$`, commit)
+}
+
+func TestVersionOfTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected model.Version
+		ok       bool
+	}{
+		{tag: "v1.2.3", expected: model.Version{Major: 1, Minor: 2, Patch: 3}, ok: true},
+		{tag: "v1.2.3.4", expected: model.Version{Major: 1, Minor: 2, Patch: 3, Build: 4}, ok: true},
+		{tag: "v1.2.3-rc.1"},
+		{tag: "v"},
+		{tag: "1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			version, ok := versionOfTag(tt.tag, "v")
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
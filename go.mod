@@ -3,13 +3,14 @@ module github.com/simp7/goversioninfo-toolkit
 go 1.23.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/josephspurrier/goversioninfo v1.4.1
 	github.com/stretchr/testify v1.11.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/akavel/rsrc v0.10.2 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCalVerScheme = errors.New("invalid calver scheme")
+)
+
+const calVerCounter = "N"
+
+// CalVerScheme is a calendar versioning scheme such as YYYY.0M.0D.N or YY.WW.N.
+// Each dot separated segment maps onto one component of Version in order, and N is a counter
+// which is incremented while the date segments stay the same and reset to zero when they change.
+type CalVerScheme struct {
+	segments []string
+}
+
+func ParseCalVerScheme(scheme string) (result CalVerScheme, err error) {
+	segments := strings.Split(scheme, ".")
+	if len(segments) > 4 {
+		err = ErrInvalidCalVerScheme
+		return
+	}
+
+	for i, segment := range segments {
+		switch segment {
+		case "YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D":
+		case calVerCounter:
+			if i != len(segments)-1 {
+				err = fmt.Errorf("%w: counter must be the last segment", ErrInvalidCalVerScheme)
+				return
+			}
+		default:
+			err = fmt.Errorf("%w: unknown segment %q", ErrInvalidCalVerScheme, segment)
+			return
+		}
+	}
+
+	result.segments = segments
+	return
+}
+
+func (c CalVerScheme) usesWeek() bool {
+	for _, segment := range c.segments {
+		if segment == "WW" || segment == "0W" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CalVerScheme) dateValue(segment string, date time.Time) int {
+	year := date.Year()
+	week := 0
+	if c.usesWeek() {
+		//주 단위 표기에서는 연말/연초가 어긋나지 않도록 ISO 연도를 사용
+		year, week = date.ISOWeek()
+	}
+
+	switch segment {
+	case "YYYY":
+		return year
+	case "YY", "0Y":
+		return year % 100
+	case "MM", "0M":
+		return int(date.Month())
+	case "WW", "0W":
+		return week
+	case "DD", "0D":
+		return date.Day()
+	}
+	return 0
+}
+
+// Next returns the version following current on the given date.
+func (c CalVerScheme) Next(current Version, date time.Time) Version {
+	currentComponents := current.components()
+	var components [4]int
+
+	sameDate := true
+	for i, segment := range c.segments {
+		if segment == calVerCounter {
+			if sameDate {
+				components[i] = currentComponents[i] + 1
+			}
+			continue
+		}
+
+		components[i] = c.dateValue(segment, date)
+		sameDate = sameDate && components[i] == currentComponents[i]
+	}
+
+	return versionOf(components)
+}
+
+func (c CalVerScheme) Format(version Version) string {
+	components := version.components()
+	formatted := make([]string, len(c.segments))
+	for i, segment := range c.segments {
+		if strings.HasPrefix(segment, "0") {
+			formatted[i] = fmt.Sprintf("%02d", components[i])
+		} else {
+			formatted[i] = fmt.Sprintf("%d", components[i])
+		}
+	}
+	return strings.Join(formatted, ".")
+}
+
+func (c CalVerScheme) String() string {
+	return strings.Join(c.segments, ".")
+}
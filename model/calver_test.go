@@ -0,0 +1,134 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalVerScheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		hasError bool
+	}{
+		{
+			name:  "year month day counter",
+			input: "YYYY.0M.0D.N",
+		},
+		{
+			name:  "short year week counter",
+			input: "YY.WW.N",
+		},
+		{
+			name:  "without counter",
+			input: "YYYY.MM",
+		},
+		{
+			name:     "counter not last",
+			input:    "YYYY.N.MM",
+			hasError: true,
+		},
+		{
+			name:     "unknown segment",
+			input:    "YYYY.MMM",
+			hasError: true,
+		},
+		{
+			name:     "too many segments",
+			input:    "YYYY.MM.DD.N.N",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCalVerScheme(tt.input)
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrInvalidCalVerScheme)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input, result.String())
+			}
+		})
+	}
+}
+
+func TestCalVerSchemeNext(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		scheme   string
+		current  Version
+		date     time.Time
+		expected Version
+	}{
+		{
+			name:     "new day resets counter",
+			scheme:   "YYYY.0M.0D.N",
+			current:  Version{Major: 2024, Minor: 3, Patch: 4, Build: 7},
+			date:     date,
+			expected: Version{Major: 2024, Minor: 3, Patch: 5, Build: 0},
+		},
+		{
+			name:     "same day increments counter",
+			scheme:   "YYYY.0M.0D.N",
+			current:  Version{Major: 2024, Minor: 3, Patch: 5, Build: 7},
+			date:     date,
+			expected: Version{Major: 2024, Minor: 3, Patch: 5, Build: 8},
+		},
+		{
+			name:     "short year and week",
+			scheme:   "YY.WW.N",
+			current:  Version{Major: 24, Minor: 10, Patch: 2},
+			date:     date,
+			expected: Version{Major: 24, Minor: 10, Patch: 3},
+		},
+		{
+			name:     "week uses ISO year",
+			scheme:   "YY.WW.N",
+			current:  Version{Major: 24, Minor: 52, Patch: 2},
+			date:     time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+			expected: Version{Major: 25, Minor: 1, Patch: 0},
+		},
+		{
+			name:     "without counter",
+			scheme:   "YYYY.MM",
+			current:  Version{Major: 2023, Minor: 12, Patch: 1, Build: 1},
+			date:     date,
+			expected: Version{Major: 2024, Minor: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, err := ParseCalVerScheme(tt.scheme)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scheme.Next(tt.current, tt.date))
+		})
+	}
+}
+
+func TestCalVerSchemeFormat(t *testing.T) {
+	version := Version{Major: 2024, Minor: 3, Patch: 5, Build: 1}
+
+	tests := []struct {
+		scheme   string
+		expected string
+	}{
+		{scheme: "YYYY.0M.0D.N", expected: "2024.03.05.1"},
+		{scheme: "YYYY.MM.DD.N", expected: "2024.3.5.1"},
+		{scheme: "YYYY.MM", expected: "2024.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			scheme, err := ParseCalVerScheme(tt.scheme)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scheme.Format(version))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown version info format")
+)
+
+// Format is the file format a version info is written in.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// Codec reads and writes version info in a format. Keys are the same in every format, e.g. FixedFileInfo.FileVersion.Major.
+type Codec interface {
+	// Decode returns the version info in data. Malformed data is a ParseError.
+	Decode(data []byte) (Info, error)
+	// Encode writes the whole info.
+	Encode(info Info) ([]byte, error)
+	// Patch returns original with only the values that differ from info rewritten, so that comments are kept.
+	// Empty original is written as a whole.
+	Patch(original []byte, info Info) ([]byte, error)
+}
+
+var codecs = map[Format]Codec{
+	FormatJSON: jsonCodec{},
+	FormatYAML: yamlCodec{},
+	FormatTOML: tomlCodec{},
+}
+
+var formatExtensions = map[string]Format{
+	".json":  FormatJSON,
+	".jsonc": FormatJSON,
+	".yaml":  FormatYAML,
+	".yml":   FormatYAML,
+	".toml":  FormatTOML,
+}
+
+// RegisterCodec adds a format with the file extensions, e.g. .json, which select it.
+// It is not safe to call concurrently with other functions of codecs, so it is meant to be called from init.
+func RegisterCodec(format Format, codec Codec, extensions ...string) {
+	codecs[format] = codec
+	for _, extension := range extensions {
+		formatExtensions[strings.ToLower(extension)] = format
+	}
+}
+
+func CodecOf(format Format) (Codec, error) {
+	codec, ok := codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+	return codec, nil
+}
+
+// FormatOf returns the format of fileName by its extension, which is JSON for unknown extensions.
+func FormatOf(fileName string) Format {
+	if format, ok := formatExtensions[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return format
+	}
+	return FormatJSON
+}
+
+// HasFormatExtension reports whether the extension of fileName selects a format, e.g. .yaml.
+func HasFormatExtension(fileName string) bool {
+	_, ok := formatExtensions[strings.ToLower(filepath.Ext(fileName))]
+	return ok
+}
+
+// Formats returns every registered format in alphabetical order.
+func Formats() (result []Format) {
+	for format := range codecs {
+		result = append(result, format)
+	}
+	slices.Sort(result)
+	return
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(data []byte) (Info, error) {
+	return ParseVersionInfo(data)
+}
+
+func (jsonCodec) Encode(info Info) ([]byte, error) {
+	return StringifyVersionInfo(info)
+}
+
+func (jsonCodec) Patch(original []byte, info Info) ([]byte, error) {
+	return PatchVersionInfo(original, info)
+}
+
+// position is where a value starts in a document.
+type position struct {
+	line   int
+	column int
+}
+
+// infoOf converts value decoded from another format into Info through JSON, so that keys and values are read as in JSON.
+// Positions are looked up by the dotted path of a value to locate type errors.
+func infoOf(value any, positions map[string]position) (result Info, err error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Info{}, ParseError{Err: err}
+	}
+
+	if err = json.Unmarshal(data, &result); err != nil {
+		parseErr := ParseError{Err: err}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			parseErr.Line, parseErr.Column = positions[typeErr.Field].line, positions[typeErr.Field].column
+		}
+		return Info{}, parseErr
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]Format{
+		"versioninfo.json":    FormatJSON,
+		"versioninfo.jsonc":   FormatJSON,
+		"dir/versioninfo.yml": FormatYAML,
+		"versioninfo.YAML":    FormatYAML,
+		"versioninfo.toml":    FormatTOML,
+		"versioninfo":         FormatJSON,
+		"-":                   FormatJSON,
+	}
+	for fileName, expected := range tests {
+		assert.Equal(t, expected, FormatOf(fileName), fileName)
+	}
+
+	assert.True(t, HasFormatExtension("versioninfo.yml"))
+	assert.False(t, HasFormatExtension("versioninfo.txt"))
+}
+
+func TestCodecOf(t *testing.T) {
+	assert.Equal(t, []Format{FormatJSON, FormatTOML, FormatYAML}, Formats())
+
+	_, err := CodecOf("xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	info := DefaultInfo()
+	info.StringFileInfo.CompanyName = "Example \"Corp\"\n"
+	info.StringFileInfo.LegalCopyright = "© 2025"
+	info.IconPath = "icon.ico"
+
+	for _, format := range Formats() {
+		t.Run(string(format), func(t *testing.T) {
+			codec, err := CodecOf(format)
+			require.NoError(t, err)
+
+			data, err := codec.Encode(info)
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(data)
+			require.NoError(t, err)
+			assert.Equal(t, info, decoded)
+
+			patched, err := codec.Patch(data, info)
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(patched))
+
+			patched, err = codec.Patch(nil, info)
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(patched))
+		})
+	}
+}
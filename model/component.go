@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownComponent  = errors.New("unknown version component")
+	ErrVersionOutOfRange = errors.New("version component out of range")
+)
+
+// ComponentChange assigns Value to one component of a version, or adds Value to it when Relative.
+// Unlike Version.Updated, the lower components are kept as they are.
+type ComponentChange struct {
+	Component VersionLevel
+	Value     int
+	Relative  bool
+}
+
+func (v *Version) component(level VersionLevel) (*int, error) {
+	switch level {
+	case LevelMajor:
+		return &v.Major, nil
+	case LevelMinor:
+		return &v.Minor, nil
+	case LevelPatch:
+		return &v.Patch, nil
+	case LevelBuild:
+		return &v.Build, nil
+	}
+	return nil, fmt.Errorf("%w: %q, expected major, minor, patch or build", ErrUnknownComponent, level)
+}
+
+// CheckRange returns ErrVersionOutOfRange when a component does not fit in the 16 bits of FixedFileInfo.
+func (v Version) CheckRange() error {
+	for i, component := range v.components() {
+		if component < 0 || component > maxVersionComponent {
+			return fmt.Errorf("%w: component %d of %s is %d, which does not fit in 16 bits", ErrVersionOutOfRange, i+1, v.String(NotationDetail), component)
+		}
+	}
+	return nil
+}
+
+func (v Version) ComponentSet(level VersionLevel, value int) (Version, error) {
+	return v.Changed(ComponentChange{Component: level, Value: value})
+}
+
+// ComponentAdded adds delta to the component of level without resetting the lower ones. Negative delta rolls it back.
+func (v Version) ComponentAdded(level VersionLevel, delta int) (Version, error) {
+	return v.Changed(ComponentChange{Component: level, Value: delta, Relative: true})
+}
+
+// Changed applies changes in order. Only the result is checked against the range, so that
+// a component can be decremented below 0 and incremented back in the same call.
+func (v Version) Changed(changes ...ComponentChange) (Version, error) {
+	result := v
+	for _, change := range changes {
+		component, err := result.component(change.Component)
+		if err != nil {
+			return v, err
+		}
+
+		if change.Relative {
+			*component += change.Value
+		} else {
+			*component = change.Value
+		}
+	}
+
+	if err := result.CheckRange(); err != nil {
+		return v, err
+	}
+	return result, nil
+}
+
+// ComponentsChanged applies changes to the versions of target. SemVer strings are not supported,
+// as the build component is the pre-release counter there.
+func (i Info) ComponentsChanged(changes []ComponentChange, target VersionTarget, notation VersionNotation) (result Info, err error) {
+	if notation == NotationSemVer {
+		return i, fmt.Errorf("%w: components can not be changed in semver notation", ErrInvalidSemVer)
+	}
+
+	result = i
+	if target != TargetProduct {
+		version, err := i.GetFileVersion()
+		if err != nil {
+			return i, err
+		}
+		if version, err = version.Changed(changes...); err != nil {
+			return i, err
+		}
+		result = result.FileVersionUpdated(version, notation)
+	}
+
+	if target != TargetFile {
+		version, err := i.GetProductVersion()
+		if err != nil {
+			return i, err
+		}
+		if version, err = version.Changed(changes...); err != nil {
+			return i, err
+		}
+		result = result.ProductVersionUpdated(version, notation)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionChanged(t *testing.T) {
+	version := Version{Major: 1, Minor: 2, Patch: 3, Build: 4}
+
+	tests := []struct {
+		name     string
+		changes  []ComponentChange
+		expected Version
+		err      error
+	}{
+		{
+			name:     "set",
+			changes:  []ComponentChange{{Component: LevelBuild, Value: 1234}},
+			expected: Version{Major: 1, Minor: 2, Patch: 3, Build: 1234},
+		},
+		{
+			name:     "increment without reset",
+			changes:  []ComponentChange{{Component: LevelMinor, Value: 1, Relative: true}},
+			expected: Version{Major: 1, Minor: 3, Patch: 3, Build: 4},
+		},
+		{
+			name:     "rollback",
+			changes:  []ComponentChange{{Component: LevelPatch, Value: -2, Relative: true}},
+			expected: Version{Major: 1, Minor: 2, Patch: 1, Build: 4},
+		},
+		{
+			name: "in order",
+			changes: []ComponentChange{
+				{Component: LevelBuild, Value: -10, Relative: true},
+				{Component: LevelBuild, Value: 10, Relative: true},
+				{Component: LevelMajor, Value: 2},
+			},
+			expected: Version{Major: 2, Minor: 2, Patch: 3, Build: 4},
+		},
+		{
+			name:    "below zero",
+			changes: []ComponentChange{{Component: LevelPatch, Value: -4, Relative: true}},
+			err:     ErrVersionOutOfRange,
+		},
+		{
+			name:    "over 16 bits",
+			changes: []ComponentChange{{Component: LevelBuild, Value: 65536}},
+			err:     ErrVersionOutOfRange,
+		},
+		{
+			name:    "unknown component",
+			changes: []ComponentChange{{Component: LevelRelease, Value: 1}},
+			err:     ErrUnknownComponent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := version.Changed(tt.changes...)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, version, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestVersionComponentSetAndAdded(t *testing.T) {
+	version, err := Version{Major: 1, Minor: 2}.ComponentSet(LevelPatch, 65535)
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 2, Patch: 65535}, version)
+
+	_, err = version.ComponentAdded(LevelPatch, 1)
+	assert.ErrorIs(t, err, ErrVersionOutOfRange)
+
+	version, err = version.ComponentAdded(LevelMinor, -1)
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 1, Patch: 65535}, version)
+}
+
+func TestVersionCheckRange(t *testing.T) {
+	assert.NoError(t, Version{Major: 65535, Build: 65535}.CheckRange())
+	assert.ErrorIs(t, Version{Minor: -1}.CheckRange(), ErrVersionOutOfRange)
+	assert.ErrorIs(t, Version{Build: 70000}.CheckRange(), ErrVersionOutOfRange)
+}
+
+func TestInfoComponentsChanged(t *testing.T) {
+	info := Info{}
+	info.StringFileInfo.FileVersion = "1.2.3.4"
+	info.StringFileInfo.ProductVersion = "1.2.3"
+	changes := []ComponentChange{{Component: LevelBuild, Value: 1234}}
+
+	result, err := info.ComponentsChanged(changes, TargetFile, NotationDetail)
+	require.NoError(t, err)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 2, Patch: 3, Build: 1234}, result.FixedFileInfo.FileVersion)
+	assert.Equal(t, "1.2.3.1234", result.StringFileInfo.FileVersion)
+	assert.Equal(t, "1.2.3", result.StringFileInfo.ProductVersion)
+
+	_, err = info.ComponentsChanged(changes, TargetBoth, NotationSemVer)
+	assert.ErrorIs(t, err, ErrInvalidSemVer)
+
+	_, err = info.ComponentsChanged([]ComponentChange{{Component: LevelMajor, Value: -2, Relative: true}}, TargetProduct, NotationNormal)
+	assert.ErrorIs(t, err, ErrVersionOutOfRange)
+}
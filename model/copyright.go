@@ -0,0 +1,42 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// copyrightYearPattern matches a year or a range of years such as 2019-2025, of which the end is submatch 3.
+var copyrightYearPattern = regexp.MustCompile(`\b((?:19|20)\d{2})(?:(\s*[-–]\s*)((?:19|20)\d{2}))?\b`)
+
+// CopyrightYearUpdated extends the last year or range of years in copyright to year,
+// e.g. 2019 to 2019-2025 and 2019-2024 to 2019-2025. found is false when copyright has no year.
+// A year which is already year or later is kept.
+func CopyrightYearUpdated(copyright string, year int) (result string, found bool) {
+	matches := copyrightYearPattern.FindAllStringSubmatchIndex(copyright, -1)
+	if len(matches) == 0 {
+		return copyright, false
+	}
+
+	last := matches[len(matches)-1]
+	start, end := last[2], last[3]
+	if last[6] >= 0 {
+		start, end = last[6], last[7]
+	}
+
+	current, _ := strconv.Atoi(copyright[start:end])
+	if current >= year {
+		return copyright, true
+	}
+
+	if last[6] >= 0 {
+		return copyright[:start] + strconv.Itoa(year) + copyright[end:], true
+	}
+	return copyright[:end] + "-" + strconv.Itoa(year) + copyright[end:], true
+}
+
+// CopyrightYearUpdated extends the year of LegalCopyright to year. found is false when it has no year.
+func (i Info) CopyrightYearUpdated(year int) (result Info, found bool) {
+	result = i
+	result.StringFileInfo.LegalCopyright, found = CopyrightYearUpdated(i.StringFileInfo.LegalCopyright, year)
+	return
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyrightYearUpdated(t *testing.T) {
+	tests := []struct {
+		name      string
+		copyright string
+		expected  string
+		found     bool
+	}{
+		{name: "single year", copyright: "Copyright (c) 2019 Example Corp", expected: "Copyright (c) 2019-2025 Example Corp", found: true},
+		{name: "range", copyright: "© 2019-2024 Example Corp", expected: "© 2019-2025 Example Corp", found: true},
+		{name: "range with spaces", copyright: "Copyright 2019 – 2024", expected: "Copyright 2019 – 2025", found: true},
+		{name: "up to date", copyright: "Copyright 2019-2025", expected: "Copyright 2019-2025", found: true},
+		{name: "current year", copyright: "Copyright 2025", expected: "Copyright 2025", found: true},
+		{name: "future year", copyright: "Copyright 2026", expected: "Copyright 2026", found: true},
+		{name: "last of many", copyright: "Copyright 2015, 2019-2023", expected: "Copyright 2015, 2019-2025", found: true},
+		{name: "no year", copyright: "Copyright Example Corp", expected: "Copyright Example Corp"},
+		{name: "not a year", copyright: "Copyright Example 12345", expected: "Copyright Example 12345"},
+		{name: "empty", copyright: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := CopyrightYearUpdated(tt.copyright, 2025)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestInfoCopyrightYearUpdated(t *testing.T) {
+	info := Info{}
+	info.StringFileInfo.LegalCopyright = "Copyright 2024"
+
+	result, found := info.CopyrightYearUpdated(2025)
+	assert.True(t, found)
+	assert.Equal(t, "Copyright 2024-2025", result.StringFileInfo.LegalCopyright)
+	assert.Equal(t, "Copyright 2024", info.StringFileInfo.LegalCopyright)
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// NotFoundError is returned when a version info file does not exist. It matches fs.ErrNotExist.
+type NotFoundError struct {
+	FileName string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s: file not found", e.FileName)
+}
+
+func (e NotFoundError) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// ParseError is returned when a version info can not be decoded. Line and Column start from 1, and are 0 when unknown.
+type ParseError struct {
+	FileName string
+	Line     int
+	Column   int
+	Err      error
+}
+
+func (e ParseError) Error() string {
+	position := e.FileName
+	if position == "" {
+		position = "version info"
+	}
+	if e.Line > 0 {
+		position += fmt.Sprintf(":%d", e.Line)
+	}
+	if e.Line > 0 && e.Column > 0 {
+		position += fmt.Sprintf(":%d", e.Column)
+	}
+	return fmt.Sprintf("%s: %s", position, e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseErrorOf returns err as a ParseError with the position in data where decoding failed.
+func parseErrorOf(data []byte, err error) ParseError {
+	offset := int64(-1)
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		//Offset은 오류가 난 바이트까지 읽은 길이
+		offset = max(syntaxErr.Offset-1, 0)
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+
+	return parseErrorAt(data, int(offset), err)
+}
+
+// parseErrorAt returns err as a ParseError at offset of data, which has no position when offset is out of data.
+func parseErrorAt(data []byte, offset int, err error) ParseError {
+	result := ParseError{Err: err}
+	if offset >= 0 && offset <= len(data) {
+		before := data[:offset]
+		result.Line = bytes.Count(before, []byte("\n")) + 1
+		result.Column = len(before) - bytes.LastIndexByte(before, '\n')
+	}
+	return result
+}
+
+// ValidationError is returned when a version info has issues which must be fixed.
+type ValidationError struct {
+	FileName string
+	Issues   []Issue
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %d issues found", e.FileName, len(e.Issues))
+}
+
+// RenderError is returned when a StringFileInfo value can not be rendered.
+type RenderError struct {
+	Field StringField
+	Err   error
+}
+
+func (e RenderError) Error() string {
+	return fmt.Sprintf("%s%s: %s", stringFileInfoFieldPrefix, e.Field, e.Err)
+}
+
+func (e RenderError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotFoundError(t *testing.T) {
+	err := error(NotFoundError{FileName: "versioninfo.json"})
+
+	assert.EqualError(t, err, "versioninfo.json: file not found")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		line     int
+		column   int
+		expected string
+	}{
+		{
+			name:     "syntax",
+			data:     "{\n\t\"StringFileInfo\": {\n\t\t\"FileVersion\": 1.0.0\n\t}\n}",
+			line:     3,
+			column:   21,
+			expected: "version info:3:21: invalid character '.' after object key:value pair",
+		},
+		{
+			name:   "type",
+			data:   "{\"FixedFileInfo\": {\"FileVersion\": {\"Major\": \"1\"}}}",
+			line:   1,
+			column: 48,
+		},
+		{
+			name: "empty",
+			data: "",
+			line: 1, column: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseVersionInfo([]byte(tt.data))
+
+			var parseErr ParseError
+			require.True(t, errors.As(err, &parseErr), "%v", err)
+			assert.Equal(t, tt.line, parseErr.Line)
+			assert.Equal(t, tt.column, parseErr.Column)
+			if tt.expected != "" {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := ValidationError{FileName: "versioninfo.json", Issues: []Issue{{Code: IssueMissingVersion}}}
+
+	assert.EqualError(t, err, "versioninfo.json: 1 issues found")
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/josephspurrier/goversioninfo"
+)
+
+var (
+	ErrUnknownStringField = errors.New("unknown StringFileInfo field")
+)
+
+// StringField is the name of a value in StringFileInfo.
+type StringField string
+
+const (
+	FieldComments         StringField = "Comments"
+	FieldCompanyName      StringField = "CompanyName"
+	FieldFileDescription  StringField = "FileDescription"
+	FieldFileVersion      StringField = "FileVersion"
+	FieldInternalName     StringField = "InternalName"
+	FieldLegalCopyright   StringField = "LegalCopyright"
+	FieldLegalTrademarks  StringField = "LegalTrademarks"
+	FieldOriginalFilename StringField = "OriginalFilename"
+	FieldPrivateBuild     StringField = "PrivateBuild"
+	FieldProductName      StringField = "ProductName"
+	FieldProductVersion   StringField = "ProductVersion"
+	FieldSpecialBuild     StringField = "SpecialBuild"
+)
+
+// StringFields are all fields of StringFileInfo in the order goversioninfo writes them.
+var StringFields = []StringField{
+	FieldComments,
+	FieldCompanyName,
+	FieldFileDescription,
+	FieldFileVersion,
+	FieldInternalName,
+	FieldLegalCopyright,
+	FieldLegalTrademarks,
+	FieldOriginalFilename,
+	FieldPrivateBuild,
+	FieldProductName,
+	FieldProductVersion,
+	FieldSpecialBuild,
+}
+
+// ParseStringField returns the field with the given name, ignoring case.
+func ParseStringField(name string) (StringField, error) {
+	for _, field := range StringFields {
+		if strings.EqualFold(string(field), name) {
+			return field, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownStringField, name)
+}
+
+func stringFieldOf(info *goversioninfo.StringFileInfo, field StringField) *string {
+	switch field {
+	case FieldComments:
+		return &info.Comments
+	case FieldCompanyName:
+		return &info.CompanyName
+	case FieldFileDescription:
+		return &info.FileDescription
+	case FieldFileVersion:
+		return &info.FileVersion
+	case FieldInternalName:
+		return &info.InternalName
+	case FieldLegalCopyright:
+		return &info.LegalCopyright
+	case FieldLegalTrademarks:
+		return &info.LegalTrademarks
+	case FieldOriginalFilename:
+		return &info.OriginalFilename
+	case FieldPrivateBuild:
+		return &info.PrivateBuild
+	case FieldProductName:
+		return &info.ProductName
+	case FieldProductVersion:
+		return &info.ProductVersion
+	case FieldSpecialBuild:
+		return &info.SpecialBuild
+	}
+	return nil
+}
+
+func (i Info) StringField(field StringField) string {
+	if value := stringFieldOf(&i.StringFileInfo, field); value != nil {
+		return *value
+	}
+	return ""
+}
+
+func (i Info) StringFieldUpdated(field StringField, value string) (result Info, err error) {
+	result = i
+	target := stringFieldOf(&result.StringFileInfo, field)
+	if target == nil {
+		return i, fmt.Errorf("%w: %q", ErrUnknownStringField, field)
+	}
+
+	*target = value
+	return result, nil
+}
+
+func (i Info) CommentsUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.Comments = value
+	return result
+}
+
+func (i Info) CompanyNameUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.CompanyName = value
+	return result
+}
+
+func (i Info) FileDescriptionUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.FileDescription = value
+	return result
+}
+
+func (i Info) InternalNameUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.InternalName = value
+	return result
+}
+
+func (i Info) LegalCopyrightUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.LegalCopyright = value
+	return result
+}
+
+func (i Info) LegalTrademarksUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.LegalTrademarks = value
+	return result
+}
+
+func (i Info) OriginalFilenameUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.OriginalFilename = value
+	return result
+}
+
+func (i Info) PrivateBuildUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.PrivateBuild = value
+	return result
+}
+
+func (i Info) ProductNameUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.ProductName = value
+	return result
+}
+
+func (i Info) SpecialBuildUpdated(value string) Info {
+	result := i
+	result.StringFileInfo.SpecialBuild = value
+	return result
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStringField(t *testing.T) {
+	field, err := ParseStringField("companyname")
+	require.NoError(t, err)
+	assert.Equal(t, FieldCompanyName, field)
+
+	_, err = ParseStringField("Company")
+	assert.ErrorIs(t, err, ErrUnknownStringField)
+}
+
+func TestStringFieldUpdated(t *testing.T) {
+	info := Info{}
+
+	for _, field := range StringFields {
+		t.Run(string(field), func(t *testing.T) {
+			result, err := info.StringFieldUpdated(field, "value of "+string(field))
+			require.NoError(t, err)
+			assert.Equal(t, "value of "+string(field), result.StringField(field))
+			assert.Equal(t, "", info.StringField(field))
+		})
+	}
+
+	_, err := info.StringFieldUpdated("Unknown", "value")
+	assert.ErrorIs(t, err, ErrUnknownStringField)
+	assert.Equal(t, "", info.StringField("Unknown"))
+}
+
+func TestTypedStringFieldUpdated(t *testing.T) {
+	info := Info{}.
+		CommentsUpdated("comments").
+		CompanyNameUpdated("company").
+		FileDescriptionUpdated("description").
+		InternalNameUpdated("internal").
+		LegalCopyrightUpdated("copyright").
+		LegalTrademarksUpdated("trademarks").
+		OriginalFilenameUpdated("app.exe").
+		PrivateBuildUpdated("private").
+		ProductNameUpdated("product").
+		SpecialBuildUpdated("special")
+
+	assert.Equal(t, "comments", info.StringFileInfo.Comments)
+	assert.Equal(t, "company", info.StringFileInfo.CompanyName)
+	assert.Equal(t, "description", info.StringFileInfo.FileDescription)
+	assert.Equal(t, "internal", info.StringFileInfo.InternalName)
+	assert.Equal(t, "copyright", info.StringFileInfo.LegalCopyright)
+	assert.Equal(t, "trademarks", info.StringFileInfo.LegalTrademarks)
+	assert.Equal(t, "app.exe", info.StringFileInfo.OriginalFilename)
+	assert.Equal(t, "private", info.StringFileInfo.PrivateBuild)
+	assert.Equal(t, "product", info.StringFileInfo.ProductName)
+	assert.Equal(t, "special", info.StringFileInfo.SpecialBuild)
+}
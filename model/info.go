@@ -2,14 +2,34 @@ package model
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/josephspurrier/goversioninfo"
 )
 
 type Info goversioninfo.VersionInfo
 
+// DefaultInfo returns the version info used for newly created files, with the flags and translation
+// suggested by goversioninfo for a US English Unicode executable.
+func DefaultInfo() (result Info) {
+	result = Info{}.VersionUpdated(Version{Major: 1}, Version{Major: 1}, TargetBoth, NotationDetail)
+
+	result.FixedFileInfo.FileFlagsMask = "3f"
+	result.FixedFileInfo.FileFlags = "00"
+	result.FixedFileInfo.FileOS = "040004"
+	result.FixedFileInfo.FileType = "01"
+	result.FixedFileInfo.FileSubType = "00"
+
+	result.VarFileInfo.Translation.LangID = 0x0409
+	result.VarFileInfo.Translation.CharsetID = goversioninfo.CsUnicode
+
+	return result
+}
+
 func ParseVersionInfo(data []byte) (version Info, err error) {
-	err = json.Unmarshal(data, &version)
+	if err = json.Unmarshal(standardJSON(data), &version); err != nil {
+		err = parseErrorOf(data, err)
+	}
 	return
 }
 
@@ -20,7 +40,7 @@ func StringifyVersionInfo(info Info) ([]byte, error) {
 func (i Info) GetFileVersion() (target Version, err error) {
 	target = Version(i.FixedFileInfo.FileVersion)
 	if target.isEmpty() {
-		target, err = parseVersion(i.StringFileInfo.FileVersion)
+		target, err = ParseVersion(i.StringFileInfo.FileVersion)
 	}
 	return
 }
@@ -28,29 +48,37 @@ func (i Info) GetFileVersion() (target Version, err error) {
 func (i Info) GetProductVersion() (target Version, err error) {
 	target = Version(i.FixedFileInfo.ProductVersion)
 	if target.isEmpty() {
-		target, err = parseVersion(i.StringFileInfo.ProductVersion)
+		target, err = ParseVersion(i.StringFileInfo.ProductVersion)
 	}
 	return
 }
 
-func (i Info) FileVersionUpdated(version Version, notation VersionNotation) (result Info) {
+func (i Info) fileVersionSet(version Version, versionString string) (result Info) {
 	result = i
 
 	result.FixedFileInfo.FileVersion = goversioninfo.FileVersion(version)
-	result.StringFileInfo.FileVersion = version.String(notation)
+	result.StringFileInfo.FileVersion = versionString
 
 	return result
 }
 
-func (i Info) ProductVersionUpdated(version Version, notation VersionNotation) (result Info) {
+func (i Info) productVersionSet(version Version, versionString string) (result Info) {
 	result = i
 
 	result.FixedFileInfo.ProductVersion = goversioninfo.FileVersion(version)
-	result.StringFileInfo.ProductVersion = version.String(notation)
+	result.StringFileInfo.ProductVersion = versionString
 
 	return result
 }
 
+func (i Info) FileVersionUpdated(version Version, notation VersionNotation) (result Info) {
+	return i.fileVersionSet(version, version.String(notation))
+}
+
+func (i Info) ProductVersionUpdated(version Version, notation VersionNotation) (result Info) {
+	return i.productVersionSet(version, version.String(notation))
+}
+
 func (i Info) VersionUpdated(fileVersion Version, productVersion Version, target VersionTarget, notation VersionNotation) (result Info) {
 	result = i
 	switch target {
@@ -64,3 +92,175 @@ func (i Info) VersionUpdated(fileVersion Version, productVersion Version, target
 	}
 	return
 }
+
+// TargetUpdate is how the version of one target is bumped. An empty level leaves the target as it is.
+// PreRelease and Metadata are used with NotationSemVer, and PreRelease, Commit and Date with template notations.
+type TargetUpdate struct {
+	Level      VersionLevel
+	Notation   VersionNotation
+	PreRelease string
+	Metadata   string
+	Commit     string
+	Date       time.Time
+}
+
+func (u TargetUpdate) applyTemplate(versionString string, fixed Version) (Version, string, error) {
+	t, err := u.Notation.Template()
+	if err != nil {
+		return fixed, versionString, err
+	}
+
+	version := fixed
+	if version.isEmpty() {
+		//템플릿으로 쓰이지 않은 문자열은 일반 표기로 읽음
+		if version, err = t.Parse(versionString); err != nil {
+			if version, err = ParseVersion(versionString); err != nil {
+				return fixed, versionString, err
+			}
+		}
+	}
+
+	version = version.Updated(u.Level)
+	if err = version.CheckRange(); err != nil {
+		return fixed, versionString, err
+	}
+
+	result, err := t.Execute(version, TemplateContext{PreRelease: u.PreRelease, Commit: u.Commit, Date: u.Date})
+	if err != nil {
+		return fixed, versionString, err
+	}
+	return version, result, nil
+}
+
+func (u TargetUpdate) apply(versionString string, fixed Version) (Version, string, error) {
+	if u.Notation.IsTemplate() {
+		return u.applyTemplate(versionString, fixed)
+	}
+
+	if u.Notation == NotationSemVer {
+		version, err := semVerOf(versionString, fixed)
+		if err != nil {
+			return fixed, versionString, err
+		}
+
+		if version, err = version.Updated(u.Level, u.PreRelease).WithMetadata(u.Metadata); err != nil {
+			return fixed, versionString, err
+		}
+		if err = version.CheckRange(); err != nil {
+			return fixed, versionString, err
+		}
+		return version.Version(), version.String(), nil
+	}
+
+	version := fixed
+	if version.isEmpty() {
+		var err error
+		if version, err = ParseVersion(versionString); err != nil {
+			return fixed, versionString, err
+		}
+	}
+
+	version = version.Updated(u.Level)
+	if err := version.CheckRange(); err != nil {
+		return fixed, versionString, err
+	}
+	return version, version.String(u.Notation), nil
+}
+
+// LevelsUpdated bumps the file and product versions independently, such as the file version on every build
+// and the product version only on releases.
+func (i Info) LevelsUpdated(file TargetUpdate, product TargetUpdate) (result Info, err error) {
+	result = i
+
+	if file.Level != "" {
+		version, versionString, err := file.apply(i.StringFileInfo.FileVersion, Version(i.FixedFileInfo.FileVersion))
+		if err != nil {
+			return i, err
+		}
+		result = result.fileVersionSet(version, versionString)
+	}
+
+	if product.Level != "" {
+		version, versionString, err := product.apply(i.StringFileInfo.ProductVersion, Version(i.FixedFileInfo.ProductVersion))
+		if err != nil {
+			return i, err
+		}
+		result = result.productVersionSet(version, versionString)
+	}
+
+	return result, nil
+}
+
+func (i Info) GetFileSemVer() (SemVer, error) {
+	return semVerOf(i.StringFileInfo.FileVersion, Version(i.FixedFileInfo.FileVersion))
+}
+
+func (i Info) GetProductSemVer() (SemVer, error) {
+	return semVerOf(i.StringFileInfo.ProductVersion, Version(i.FixedFileInfo.ProductVersion))
+}
+
+func semVerOf(versionString string, fixed Version) (SemVer, error) {
+	if versionString == "" {
+		return fixed.SemVer(), nil
+	}
+
+	result, err := ParseSemVer(versionString)
+	if err != nil {
+		//SemVer가 아닌 기존 표기(1.2.3.4 등)도 받아들임
+		version, parseErr := ParseVersion(versionString)
+		if parseErr != nil {
+			return SemVer{}, err
+		}
+		return version.SemVer(), nil
+	}
+	return result, nil
+}
+
+func (i Info) FileSemVerUpdated(version SemVer) (result Info) {
+	return i.fileVersionSet(version.Version(), version.String())
+}
+
+func (i Info) ProductSemVerUpdated(version SemVer) (result Info) {
+	return i.productVersionSet(version.Version(), version.String())
+}
+
+func (i Info) SemVerUpdated(fileVersion SemVer, productVersion SemVer, target VersionTarget) (result Info) {
+	result = i
+	switch target {
+	case TargetFile:
+		result = result.FileSemVerUpdated(fileVersion)
+	case TargetProduct:
+		result = result.ProductSemVerUpdated(productVersion)
+	case TargetBoth:
+		result = result.FileSemVerUpdated(fileVersion)
+		result = result.ProductSemVerUpdated(productVersion)
+	}
+	return
+}
+
+func (i Info) CalVerUpdated(scheme CalVerScheme, date time.Time, target VersionTarget) (result Info, err error) {
+	fileVersion, err := i.GetFileVersion()
+	if err != nil {
+		return i, err
+	}
+
+	productVersion, err := i.GetProductVersion()
+	if err != nil {
+		return i, err
+	}
+
+	fileVersion = scheme.Next(fileVersion, date)
+	productVersion = scheme.Next(productVersion, date)
+
+	result = i
+	switch target {
+	case TargetFile:
+		result = result.fileVersionSet(fileVersion, scheme.Format(fileVersion))
+	case TargetProduct:
+		result = result.productVersionSet(productVersion, scheme.Format(productVersion))
+	case TargetBoth:
+		result = result.fileVersionSet(fileVersion, scheme.Format(fileVersion))
+		result = result.productVersionSet(productVersion, scheme.Format(productVersion))
+	}
+	return
+}
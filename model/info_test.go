@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/josephspurrier/goversioninfo"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,19 @@ func TestParseVersionInfo(t *testing.T) {
 		_, err := ParseVersionInfo([]byte(invalidJSON))
 		assert.Error(t, err)
 	})
+
+	t.Run("comments and trailing commas", func(t *testing.T) {
+		info, err := ParseVersionInfo([]byte("{\n\t// released by CI\n\t\"StringFileInfo\": {\n\t\t\"FileVersion\": \"1.2.3.4\", /* kept */\n\t},\n}"))
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3.4", info.StringFileInfo.FileVersion)
+	})
+
+	t.Run("error after comment", func(t *testing.T) {
+		_, err := ParseVersionInfo([]byte("{\n\t/* multi\n\tline */\n\t\"StringFileInfo\": ,\n}"))
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 4, parseErr.Line)
+	})
 }
 
 func TestStringifyVersionInfo(t *testing.T) {
@@ -196,16 +210,167 @@ func TestInfoVersionUpdated(t *testing.T) {
 				assert.Equal(t, "1.2.3.4", result.StringFileInfo.FileVersion)
 				assert.Equal(t, goversioninfo.FileVersion{}, result.FixedFileInfo.ProductVersion)
 			case TargetProduct:
-				// Note: There's a bug in the original code - it uses fileVersion instead of productVersion
-				assert.Equal(t, goversioninfo.FileVersion(fileVersion), result.FixedFileInfo.ProductVersion)
-				assert.Equal(t, "1.2.3.4", result.StringFileInfo.ProductVersion)
+				assert.Equal(t, goversioninfo.FileVersion(productVersion), result.FixedFileInfo.ProductVersion)
+				assert.Equal(t, "2.3.4.5", result.StringFileInfo.ProductVersion)
 				assert.Equal(t, goversioninfo.FileVersion{}, result.FixedFileInfo.FileVersion)
 			case TargetBoth:
 				assert.Equal(t, goversioninfo.FileVersion(fileVersion), result.FixedFileInfo.FileVersion)
-				assert.Equal(t, goversioninfo.FileVersion(fileVersion), result.FixedFileInfo.ProductVersion)
+				assert.Equal(t, goversioninfo.FileVersion(productVersion), result.FixedFileInfo.ProductVersion)
 				assert.Equal(t, "1.2.3.4", result.StringFileInfo.FileVersion)
-				assert.Equal(t, "1.2.3.4", result.StringFileInfo.ProductVersion)
+				assert.Equal(t, "2.3.4.5", result.StringFileInfo.ProductVersion)
+			}
+		})
+	}
+}
+
+func TestGetFileSemVer(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     Info
+		expected SemVer
+		hasError bool
+	}{
+		{
+			name: "from SemVer string",
+			info: Info{
+				StringFileInfo: goversioninfo.StringFileInfo{FileVersion: "1.4.0-rc.2+sha.abc123"},
+			},
+			expected: SemVer{Major: 1, Minor: 4, PreRelease: []string{"rc", "2"}, Metadata: []string{"sha", "abc123"}},
+		},
+		{
+			name: "from four part string",
+			info: Info{
+				StringFileInfo: goversioninfo.StringFileInfo{FileVersion: "1.2.3.4"},
+			},
+			expected: SemVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name: "from FixedFileInfo when StringFileInfo is empty",
+			info: Info{
+				FixedFileInfo: goversioninfo.FixedFileInfo{
+					FileVersion: goversioninfo.FileVersion{Major: 2, Minor: 3, Patch: 4, Build: 5},
+				},
+			},
+			expected: SemVer{Major: 2, Minor: 3, Patch: 4},
+		},
+		{
+			name: "invalid string",
+			info: Info{
+				StringFileInfo: goversioninfo.StringFileInfo{FileVersion: "1.2.x-rc"},
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.info.GetFileSemVer()
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrInvalidSemVer)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
 			}
 		})
 	}
 }
+
+func TestInfoSemVerUpdated(t *testing.T) {
+	info := Info{}
+	fileVersion := SemVer{Major: 1, Minor: 4, PreRelease: []string{"rc", "2"}, Metadata: []string{"sha", "abc123"}}
+	productVersion := SemVer{Major: 1, Minor: 4}
+
+	result := info.SemVerUpdated(fileVersion, productVersion, TargetBoth)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 4, Patch: 0, Build: 2}, result.FixedFileInfo.FileVersion)
+	assert.Equal(t, "1.4.0-rc.2+sha.abc123", result.StringFileInfo.FileVersion)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 4, Build: 65535}, result.FixedFileInfo.ProductVersion)
+	assert.Equal(t, "1.4.0", result.StringFileInfo.ProductVersion)
+
+	result = info.SemVerUpdated(fileVersion, productVersion, TargetProduct)
+	assert.Equal(t, "", result.StringFileInfo.FileVersion)
+	assert.Equal(t, "1.4.0", result.StringFileInfo.ProductVersion)
+}
+
+func TestInfoLevelsUpdated(t *testing.T) {
+	info := Info{}
+	info.StringFileInfo.FileVersion = "1.4.0-rc.1"
+	info.StringFileInfo.ProductVersion = "1.3.2"
+
+	t.Run("pre-release counter", func(t *testing.T) {
+		result, err := info.LevelsUpdated(TargetUpdate{Level: LevelPreRelease, Notation: NotationSemVer, Metadata: "sha.abc123"}, TargetUpdate{})
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.0-rc.2+sha.abc123", result.StringFileInfo.FileVersion)
+		assert.Equal(t, 2, result.FixedFileInfo.FileVersion.Build)
+		assert.Equal(t, "1.3.2", result.StringFileInfo.ProductVersion)
+	})
+
+	t.Run("promotion to release", func(t *testing.T) {
+		update := TargetUpdate{Level: LevelRelease, Notation: NotationSemVer}
+		result, err := info.LevelsUpdated(update, update)
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.0", result.StringFileInfo.FileVersion)
+		assert.Equal(t, "1.3.2", result.StringFileInfo.ProductVersion)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		update := TargetUpdate{Level: LevelPatch, Notation: NotationSemVer, Metadata: "sha_1"}
+		_, err := info.LevelsUpdated(update, update)
+		assert.ErrorIs(t, err, ErrInvalidSemVer)
+	})
+
+	t.Run("independent levels", func(t *testing.T) {
+		info := Info{}
+		info.StringFileInfo.FileVersion = "1.2.3.4"
+		info.StringFileInfo.ProductVersion = "1.2.3"
+
+		result, err := info.LevelsUpdated(TargetUpdate{Level: LevelBuild, Notation: NotationDetail}, TargetUpdate{Level: LevelMinor, Notation: NotationNormal})
+		require.NoError(t, err)
+		assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 2, Patch: 3, Build: 5}, result.FixedFileInfo.FileVersion)
+		assert.Equal(t, "1.2.3.5", result.StringFileInfo.FileVersion)
+		assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 3}, result.FixedFileInfo.ProductVersion)
+		assert.Equal(t, "1.3.0", result.StringFileInfo.ProductVersion)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		info := Info{}
+		info.StringFileInfo.FileVersion = "1.x"
+		_, err := info.LevelsUpdated(TargetUpdate{Level: LevelPatch}, TargetUpdate{})
+		assert.Error(t, err)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		info := Info{}
+		info.StringFileInfo.FileVersion = "1.2.3.65535"
+		_, err := info.LevelsUpdated(TargetUpdate{Level: LevelBuild, Notation: NotationDetail}, TargetUpdate{})
+		assert.ErrorIs(t, err, ErrVersionOutOfRange)
+	})
+}
+
+func TestInfoCalVerUpdated(t *testing.T) {
+	info := Info{
+		StringFileInfo: goversioninfo.StringFileInfo{
+			FileVersion:    "2024.03.05.1",
+			ProductVersion: "2024.03.04.3",
+		},
+	}
+	scheme, err := ParseCalVerScheme("YYYY.0M.0D.N")
+	require.NoError(t, err)
+
+	result, err := info.CalVerUpdated(scheme, time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC), TargetBoth)
+	require.NoError(t, err)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 2024, Minor: 3, Patch: 5, Build: 2}, result.FixedFileInfo.FileVersion)
+	assert.Equal(t, "2024.03.05.2", result.StringFileInfo.FileVersion)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 2024, Minor: 3, Patch: 5, Build: 0}, result.FixedFileInfo.ProductVersion)
+	assert.Equal(t, "2024.03.05.0", result.StringFileInfo.ProductVersion)
+}
+
+func TestDefaultInfo(t *testing.T) {
+	info := DefaultInfo()
+
+	assert.Equal(t, goversioninfo.FileVersion{Major: 1}, info.FixedFileInfo.FileVersion)
+	assert.Equal(t, "1.0.0.0", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "1.0.0.0", info.StringFileInfo.ProductVersion)
+	assert.Equal(t, "040004", info.FixedFileInfo.FileOS)
+	assert.Equal(t, goversioninfo.LangID(0x0409), info.VarFileInfo.Translation.LangID)
+	assert.Equal(t, goversioninfo.CsUnicode, info.VarFileInfo.Translation.CharsetID)
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+)
+
+// standardJSON returns data with the comments and trailing commas of JSONC replaced by spaces,
+// so that encoding/json reads it while offsets of errors stay the same. Newlines in block comments are kept.
+func standardJSON(data []byte) []byte {
+	if !bytes.Contains(data, []byte("/")) && !bytes.Contains(data, []byte(",")) {
+		return data
+	}
+
+	result := bytes.Clone(data)
+	blank := func(start int, end int) {
+		for i := start; i < end; i++ {
+			if result[i] != '\n' {
+				result[i] = ' '
+			}
+		}
+	}
+
+	//comma는 값 뒤에 온 마지막 쉼표의 위치로, 닫는 괄호가 바로 뒤에 오면 지움
+	comma, previous := -1, byte(0)
+	for i := 0; i < len(result); i++ {
+		c := result[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			continue
+		case c == '/' && bytes.HasPrefix(result[i:], []byte("//")):
+			end := bytes.IndexByte(result[i:], '\n')
+			if end < 0 {
+				end = len(result) - i
+			}
+			blank(i, i+end)
+			i += end - 1
+			continue
+		case c == '/' && bytes.HasPrefix(result[i:], []byte("/*")):
+			end := bytes.Index(result[i+2:], []byte("*/"))
+			if end < 0 {
+				return result
+			}
+			blank(i, i+end+4)
+			i += end + 3
+			continue
+		case c == '"':
+			for i++; i < len(result) && result[i] != '"'; i++ {
+				if result[i] == '\\' {
+					i++
+				}
+			}
+		case c == ',' && strings.IndexByte("[{,:", previous) < 0:
+			comma, previous = i, c
+			continue
+		case (c == '}' || c == ']') && comma >= 0:
+			result[comma] = ' '
+		}
+
+		comma = -1
+		previous = c
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "plain", data: `{"a": [1, 2]}`, expected: `{"a": [1, 2]}`},
+		{name: "line comment", data: "{\"a\": 1 // one\n}", expected: "{\"a\": 1       \n}"},
+		{name: "block comment", data: "{/* a\nb */\"a\": 1}", expected: "{    \n    \"a\": 1}"},
+		{name: "trailing commas", data: `{"a": [1, 2,], "b": {"c": 3,},}`, expected: `{"a": [1, 2 ], "b": {"c": 3 } }`},
+		{name: "comma before comment", data: "[1, // one\n]", expected: "[1        \n]"},
+		{name: "comment in string", data: `{"a": "// not /* a comment */,"}`, expected: `{"a": "// not /* a comment */,"}`},
+		{name: "escaped quote", data: `{"a": "\"//"}`, expected: `{"a": "\"//"}`},
+		{name: "missing value", data: `{"a": ,}`, expected: `{"a": ,}`},
+		{name: "empty element", data: `[1,,]`, expected: `[1,,]`},
+		{name: "unterminated comment", data: `{"a": 1 /* b`, expected: `{"a": 1 /* b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(standardJSON([]byte(tt.data))))
+		})
+	}
+}
@@ -0,0 +1,510 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidJSON = errors.New("invalid json")
+)
+
+// jsonValue is the position of a value in a JSON document.
+// For object members, keyStart and keyEnd are the position of the key including its quotes.
+type jsonValue struct {
+	path     []string
+	keyStart int
+	keyEnd   int
+	start    int
+	end      int
+}
+
+type jsonDocument struct {
+	data   []byte
+	values []jsonValue
+}
+
+type jsonScanner struct {
+	data   []byte
+	offset int
+	values []jsonValue
+}
+
+func scanJSON(data []byte) (jsonDocument, error) {
+	s := &jsonScanner{data: data}
+	if err := s.value(nil, -1, -1); err != nil {
+		return jsonDocument{}, err
+	}
+
+	s.skipSpace()
+	if s.offset != len(data) {
+		return jsonDocument{}, s.errorf("unexpected %q after top-level value", data[s.offset])
+	}
+	return jsonDocument{data: data, values: s.values}, nil
+}
+
+func (s *jsonScanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidJSON, fmt.Sprintf(format, args...), s.offset)
+}
+
+// skipSpace skips whitespace and the comments of JSONC. An unterminated block comment is left to fail as a value.
+func (s *jsonScanner) skipSpace() {
+	for s.offset < len(s.data) {
+		switch rest := s.data[s.offset:]; {
+		case strings.IndexByte(" \t\r\n", rest[0]) >= 0:
+			s.offset++
+		case bytes.HasPrefix(rest, []byte("//")):
+			if end := bytes.IndexByte(rest, '\n'); end >= 0 {
+				s.offset += end
+			} else {
+				s.offset = len(s.data)
+			}
+		case bytes.HasPrefix(rest, []byte("/*")):
+			end := bytes.Index(rest[2:], []byte("*/"))
+			if end < 0 {
+				return
+			}
+			s.offset += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// closes skips c, the end of an object or array, after a trailing comma, reporting whether it is found.
+func (s *jsonScanner) closes(c byte) bool {
+	s.skipSpace()
+	if s.offset < len(s.data) && s.data[s.offset] == c {
+		s.offset++
+		return true
+	}
+	return false
+}
+
+func (s *jsonScanner) expect(c byte) error {
+	s.skipSpace()
+	if s.offset >= len(s.data) || s.data[s.offset] != c {
+		return s.errorf("expected %q", c)
+	}
+	s.offset++
+	return nil
+}
+
+func (s *jsonScanner) value(path []string, keyStart int, keyEnd int) error {
+	s.skipSpace()
+	if s.offset >= len(s.data) {
+		return s.errorf("unexpected end of input")
+	}
+
+	index := len(s.values)
+	s.values = append(s.values, jsonValue{path: path, keyStart: keyStart, keyEnd: keyEnd, start: s.offset})
+
+	var err error
+	switch s.data[s.offset] {
+	case '{':
+		err = s.object(path)
+	case '[':
+		err = s.array(path)
+	case '"':
+		_, err = s.string()
+	default:
+		err = s.literal()
+	}
+	if err != nil {
+		return err
+	}
+
+	s.values[index].end = s.offset
+	return nil
+}
+
+func (s *jsonScanner) object(path []string) error {
+	s.offset++
+	s.skipSpace()
+	if s.offset < len(s.data) && s.data[s.offset] == '}' {
+		s.offset++
+		return nil
+	}
+
+	for {
+		s.skipSpace()
+		keyStart := s.offset
+		key, err := s.string()
+		if err != nil {
+			return err
+		}
+		keyEnd := s.offset
+
+		if err = s.expect(':'); err != nil {
+			return err
+		}
+		if err = s.value(append(path[:len(path):len(path)], key), keyStart, keyEnd); err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.offset < len(s.data) && s.data[s.offset] == '}' {
+			s.offset++
+			return nil
+		}
+		if err = s.expect(','); err != nil {
+			return err
+		}
+		if s.closes('}') {
+			return nil
+		}
+	}
+}
+
+func (s *jsonScanner) array(path []string) error {
+	s.offset++
+	s.skipSpace()
+	if s.offset < len(s.data) && s.data[s.offset] == ']' {
+		s.offset++
+		return nil
+	}
+
+	for i := 0; ; i++ {
+		if err := s.value(append(path[:len(path):len(path)], strconv.Itoa(i)), -1, -1); err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.offset < len(s.data) && s.data[s.offset] == ']' {
+			s.offset++
+			return nil
+		}
+		if err := s.expect(','); err != nil {
+			return err
+		}
+		if s.closes(']') {
+			return nil
+		}
+	}
+}
+
+func (s *jsonScanner) string() (string, error) {
+	if s.offset >= len(s.data) || s.data[s.offset] != '"' {
+		return "", s.errorf("expected string")
+	}
+
+	start := s.offset
+	for s.offset++; s.offset < len(s.data); s.offset++ {
+		switch s.data[s.offset] {
+		case '\\':
+			s.offset++
+		case '"':
+			s.offset++
+			var result string
+			if err := json.Unmarshal(s.data[start:s.offset], &result); err != nil {
+				return "", s.errorf("invalid string")
+			}
+			return result, nil
+		}
+	}
+	return "", s.errorf("unterminated string")
+}
+
+func (s *jsonScanner) literal() error {
+	start := s.offset
+	for s.offset < len(s.data) && strings.IndexByte(",}]/ \t\r\n", s.data[s.offset]) < 0 {
+		s.offset++
+	}
+	if !json.Valid(s.data[start:s.offset]) {
+		return s.errorf("invalid literal %q", s.data[start:s.offset])
+	}
+	return nil
+}
+
+// find returns the value at path. Keys are matched like encoding/json does, preferring an exact match
+// over a case-insensitive one and the last of duplicated keys.
+func (d jsonDocument) find(path []string) (result jsonValue, ok bool) {
+	exact := false
+	for _, value := range d.values {
+		if len(value.path) != len(path) || !pathHasPrefix(value.path, path[:len(path)-1]) {
+			continue
+		}
+
+		key := value.path[len(path)-1]
+		switch {
+		case key == path[len(path)-1]:
+			result, ok, exact = value, true, true
+		case !exact && strings.EqualFold(key, path[len(path)-1]):
+			result, ok = value, true
+		}
+	}
+	return
+}
+
+func pathHasPrefix(path []string, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if !strings.EqualFold(path[i], prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d jsonDocument) children(parent jsonValue) (result []jsonValue) {
+	for _, value := range d.values {
+		if value.start > parent.start && value.end < parent.end && len(value.path) == len(parent.path)+1 {
+			result = append(result, value)
+		}
+	}
+	return
+}
+
+// lineIndent returns the whitespace at the beginning of the line containing offset.
+func (d jsonDocument) lineIndent(offset int) string {
+	lineStart := bytes.LastIndexByte(d.data[:offset], '\n') + 1
+	end := lineStart
+	for end < offset && (d.data[end] == ' ' || d.data[end] == '\t') {
+		end++
+	}
+	return string(d.data[lineStart:end])
+}
+
+// indentUnit guesses the indentation the document uses per level, falling back to a tab like StringifyVersionInfo.
+func (d jsonDocument) indentUnit() string {
+	for _, value := range d.values {
+		if value.keyStart < 0 {
+			continue
+		}
+		parentIndent := d.lineIndent(d.values[0].start)
+		if indent := d.lineIndent(value.keyStart); len(indent) > len(parentIndent) && len(value.path) == 1 {
+			return indent[len(parentIndent):]
+		}
+	}
+	return "\t"
+}
+
+func (d jsonDocument) replaced(start int, end int, text []byte) []byte {
+	result := make([]byte, 0, len(d.data)-(end-start)+len(text))
+	result = append(result, d.data[:start]...)
+	result = append(result, text...)
+	return append(result, d.data[end:]...)
+}
+
+// set returns the document with the value at path replaced by raw, creating missing objects on the way.
+func (d jsonDocument) set(path []string, raw json.RawMessage) ([]byte, error) {
+	if value, ok := d.find(path); ok {
+		return d.replaced(value.start, value.end, raw), nil
+	}
+
+	//가장 가까운 상위 객체를 찾아서 빠진 키를 중첩 객체로 만들어 넣음
+	depth := len(path) - 1
+	parent := d.values[0]
+	for ; depth > 0; depth-- {
+		if value, ok := d.find(path[:depth]); ok {
+			parent = value
+			break
+		}
+	}
+
+	var member any = raw
+	for i := len(path) - 1; i > depth; i-- {
+		member = map[string]any{path[i]: member}
+	}
+
+	if d.data[parent.start] != '{' {
+		text, err := d.marshalAt(map[string]any{path[depth]: member}, d.lineIndent(parent.start))
+		if err != nil {
+			return nil, err
+		}
+		return d.replaced(parent.start, parent.end, text), nil
+	}
+
+	siblings := d.children(parent)
+	if len(siblings) == 0 {
+		indent := d.lineIndent(parent.start)
+		memberIndent := indent + d.indentUnit()
+		text, err := d.marshalAt(member, memberIndent)
+		if err != nil {
+			return nil, err
+		}
+		key, _ := json.Marshal(path[depth])
+		//빈 객체 안의 주석은 남기고 닫는 괄호 앞에 넣음
+		body := bytes.TrimRight(d.data[parent.start+1:parent.end-1], " \t\r\n")
+		inserted := fmt.Sprintf("\n%s%s: %s\n%s}", memberIndent, key, text, indent)
+		return d.replaced(parent.start+1+len(body), parent.end, []byte(inserted)), nil
+	}
+
+	first, last := siblings[0], siblings[len(siblings)-1]
+	prefix := string(d.data[parent.start+1 : first.keyStart])
+	separator := string(d.data[first.keyEnd:first.start])
+	//주석이 복제되지 않도록 첫 멤버 앞의 공백과 구분자만 따라 씀
+	if strings.Contains(prefix, "\n") {
+		prefix = "\n" + d.lineIndent(first.keyStart)
+	} else if strings.TrimSpace(prefix) != "" {
+		prefix = " "
+	}
+	if strings.TrimSpace(separator) != ":" {
+		separator = ": "
+	}
+	text, err := d.marshalAt(member, d.lineIndent(first.keyStart))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(prefix, "\n") {
+		text, err = json.Marshal(member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, _ := json.Marshal(path[depth])
+	inserted := fmt.Sprintf("%s%s%s%s", prefix, key, separator, text)
+
+	//마지막 멤버 뒤의 주석은 그 멤버에 남기고 다음 줄에 넣음
+	if lineEnd, hasComma := d.commentedLineEnd(last.end); lineEnd >= 0 && strings.HasPrefix(prefix, "\n") {
+		comma := ","
+		if hasComma {
+			comma = ""
+		}
+		result := d.replaced(lineEnd, lineEnd, []byte(inserted))
+		return slices.Insert(result, last.end, []byte(comma)...), nil
+	}
+	return d.replaced(last.end, last.end, []byte(","+inserted)), nil
+}
+
+// commentedLineEnd returns the end of the line from offset if the rest of it is line or block comments with an optional comma,
+// or -1, and whether the comma is there.
+func (d jsonDocument) commentedLineEnd(offset int) (end int, comma bool) {
+	lineEnd := bytes.IndexByte(d.data[offset:], '\n')
+	if lineEnd < 0 {
+		return -1, false
+	}
+
+	commented := false
+	for rest := bytes.TrimSpace(d.data[offset : offset+lineEnd]); len(rest) > 0; rest = bytes.TrimSpace(rest) {
+		switch {
+		case rest[0] == ',' && !comma:
+			comma, rest = true, rest[1:]
+		case bytes.HasPrefix(rest, []byte("//")):
+			commented, rest = true, nil
+		case bytes.HasPrefix(rest, []byte("/*")):
+			//다음 줄까지 이어지는 블록 주석은 다루지 않음
+			closing := bytes.Index(rest[2:], []byte("*/"))
+			if closing < 0 {
+				return -1, false
+			}
+			commented, rest = true, rest[closing+4:]
+		default:
+			return -1, false
+		}
+	}
+	if !commented {
+		return -1, false
+	}
+	return offset + lineEnd, comma
+}
+
+func (d jsonDocument) marshalAt(value any, indent string) ([]byte, error) {
+	if raw, ok := value.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.MarshalIndent(value, indent, d.indentUnit())
+}
+
+// leaf is a scalar or array value of a version info with its compact JSON encoding.
+type leaf struct {
+	path []string
+	raw  json.RawMessage
+}
+
+// leaves returns the scalar and array values of info in field order.
+// Buffer and Structure are skipped, as they are filled by goversioninfo while building and are not configuration.
+func leaves(info Info) ([]leaf, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(info); err != nil {
+		return nil, err
+	}
+
+	data := bytes.TrimSpace(buffer.Bytes())
+	document, err := scanJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []leaf
+	for _, value := range document.values {
+		if len(value.path) == 0 || value.path[0] == "Buffer" || value.path[0] == "Structure" {
+			continue
+		}
+		if data[value.start] != '{' && (len(value.path) < 2 || !isArrayElement(document, value)) {
+			result = append(result, leaf{path: value.path, raw: data[value.start:value.end]})
+		}
+	}
+	return result, nil
+}
+
+func isArrayElement(document jsonDocument, value jsonValue) bool {
+	parent, ok := document.find(value.path[:len(value.path)-1])
+	return ok && document.data[parent.start] == '['
+}
+
+// changedLeaves returns the leaves of info which differ from current, so that patches rewrite only them.
+func changedLeaves(current Info, info Info) ([]leaf, error) {
+	currentLeaves, err := leaves(current)
+	if err != nil {
+		return nil, err
+	}
+	currentValues := make(map[string]string, len(currentLeaves))
+	for _, value := range currentLeaves {
+		currentValues[strings.Join(value.path, "\x00")] = string(value.raw)
+	}
+
+	updatedLeaves, err := leaves(info)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []leaf
+	for _, value := range updatedLeaves {
+		if currentValues[strings.Join(value.path, "\x00")] != string(value.raw) {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+// PatchVersionInfo returns original with only the values that differ between original and info rewritten,
+// so that unknown keys, key order, formatting and indentation of original are preserved byte-for-byte.
+// Keys missing in original are appended to their parent object.
+func PatchVersionInfo(original []byte, info Info) ([]byte, error) {
+	if len(bytes.TrimSpace(original)) == 0 {
+		return StringifyVersionInfo(info)
+	}
+
+	current, err := ParseVersionInfo(original)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := changedLeaves(current, info)
+	if err != nil {
+		return nil, err
+	}
+
+	result := original
+	for _, value := range changed {
+		document, err := scanJSON(result)
+		if err != nil {
+			return nil, err
+		}
+		if result, err = document.set(value.path, value.raw); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,251 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanJSON(t *testing.T) {
+	document, err := scanJSON([]byte(`{"a": {"b": [1, "x\"y"]}, "c": null}`))
+	require.NoError(t, err)
+
+	value, ok := document.find([]string{"a", "b"})
+	require.True(t, ok)
+	assert.Equal(t, `[1, "x\"y"]`, string(document.data[value.start:value.end]))
+
+	value, ok = document.find([]string{"C"})
+	require.True(t, ok)
+	assert.Equal(t, `null`, string(document.data[value.start:value.end]))
+
+	_, ok = document.find([]string{"a", "c"})
+	assert.False(t, ok)
+
+	document, err = scanJSON([]byte("{\n\t// a\n\t\"a\": 1/* b */, \"b\": [1, 2,],\n}"))
+	require.NoError(t, err)
+	value, ok = document.find([]string{"a"})
+	require.True(t, ok)
+	assert.Equal(t, `1`, string(document.data[value.start:value.end]))
+	value, ok = document.find([]string{"b"})
+	require.True(t, ok)
+	assert.Equal(t, `[1, 2,]`, string(document.data[value.start:value.end]))
+
+	for _, invalid := range []string{`{"a": }`, `{"a": 1,,}`, `[1,,]`, `{,}`, `{"a": 1 /* x`, `{"a" 1}`, `{"a": tru}`, `{"a": "x}`, `{} {}`} {
+		_, err := scanJSON([]byte(invalid))
+		assert.ErrorIs(t, err, ErrInvalidJSON, invalid)
+	}
+}
+
+func TestPatchVersionInfo(t *testing.T) {
+	original := `{
+  "FixedFileInfo": {
+    "FileVersion": {"Major": 1, "Minor": 2, "Patch": 3, "Build": 4},
+    "ProductVersion": {
+      "Major": 1,
+      "Minor": 2,
+      "Patch": 3,
+      "Build": 4
+    },
+    "FileFlagsMask": "3f"
+  },
+  "StringFileInfo": {
+    "ProductName": "Example",
+    "FileVersion": "1.2.3.4",
+    "ProductVersion": "1.2.3.4",
+    "X-Custom": "kept"
+  },
+  "Unknown": [1, 2, 3]
+}
+`
+
+	t.Run("version values only", func(t *testing.T) {
+		info, err := ParseVersionInfo([]byte(original))
+		require.NoError(t, err)
+		info = info.VersionUpdated(Version{Major: 1, Minor: 2, Patch: 4}, Version{Major: 1, Minor: 3}, TargetBoth, NotationDetail)
+
+		result, err := PatchVersionInfo([]byte(original), info)
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "FixedFileInfo": {
+    "FileVersion": {"Major": 1, "Minor": 2, "Patch": 4, "Build": 0},
+    "ProductVersion": {
+      "Major": 1,
+      "Minor": 3,
+      "Patch": 0,
+      "Build": 0
+    },
+    "FileFlagsMask": "3f"
+  },
+  "StringFileInfo": {
+    "ProductName": "Example",
+    "FileVersion": "1.2.4.0",
+    "ProductVersion": "1.3.0.0",
+    "X-Custom": "kept"
+  },
+  "Unknown": [1, 2, 3]
+}
+`, string(result))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		info, err := ParseVersionInfo([]byte(original))
+		require.NoError(t, err)
+
+		result, err := PatchVersionInfo([]byte(original), info)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(result))
+	})
+
+	t.Run("missing keys", func(t *testing.T) {
+		info, err := ParseVersionInfo([]byte(original))
+		require.NoError(t, err)
+		info.StringFileInfo.CompanyName = "Example <Corp> ©"
+		info.VarFileInfo.Translation.LangID = 0x0409
+
+		result, err := PatchVersionInfo([]byte(original), info)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), `    "X-Custom": "kept",
+    "CompanyName": "Example <Corp> ©"
+  },`)
+		assert.Contains(t, string(result), `  "Unknown": [1, 2, 3],
+  "VarFileInfo": {
+    "Translation": {
+      "LangID": 1033
+    }
+  }
+}`)
+
+		parsed, err := ParseVersionInfo(result)
+		require.NoError(t, err)
+		assert.Equal(t, info.StringFileInfo, parsed.StringFileInfo)
+		assert.Equal(t, info.VarFileInfo, parsed.VarFileInfo)
+	})
+
+	t.Run("compact document", func(t *testing.T) {
+		compact := `{"StringFileInfo":{"FileVersion":"1.0"}}`
+		info, err := ParseVersionInfo([]byte(compact))
+		require.NoError(t, err)
+		info = info.FileVersionUpdated(Version{Major: 1, Minor: 1}, NotationSimple)
+		info.StringFileInfo.ProductName = "Example"
+
+		result, err := PatchVersionInfo([]byte(compact), info)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), `"StringFileInfo":{"FileVersion":"1.1","ProductName":"Example"}`)
+
+		parsed, err := ParseVersionInfo(result)
+		require.NoError(t, err)
+		assert.Equal(t, 1, parsed.FixedFileInfo.FileVersion.Minor)
+	})
+
+	t.Run("empty object and null", func(t *testing.T) {
+		document := "{\n\t\"StringFileInfo\": {},\n\t\"VarFileInfo\": null\n}"
+		info, err := ParseVersionInfo([]byte(document))
+		require.NoError(t, err)
+		info.StringFileInfo.ProductName = "Example"
+		info.VarFileInfo.Translation.CharsetID = 1200
+
+		result, err := PatchVersionInfo([]byte(document), info)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), "\t\"StringFileInfo\": {\n\t\t\"ProductName\": \"Example\"\n\t},")
+
+		parsed, err := ParseVersionInfo(result)
+		require.NoError(t, err)
+		assert.Equal(t, info.StringFileInfo, parsed.StringFileInfo)
+		assert.Equal(t, info.VarFileInfo, parsed.VarFileInfo)
+	})
+
+	t.Run("case insensitive keys", func(t *testing.T) {
+		document := `{"stringfileinfo": {"fileversion": "1.0"}}`
+		info, err := ParseVersionInfo([]byte(document))
+		require.NoError(t, err)
+		info.StringFileInfo.FileVersion = "2.0"
+
+		result, err := PatchVersionInfo([]byte(document), info)
+		require.NoError(t, err)
+		assert.Equal(t, `{"stringfileinfo": {"fileversion": "2.0"}}`, string(result))
+	})
+
+	t.Run("empty original", func(t *testing.T) {
+		result, err := PatchVersionInfo(nil, DefaultInfo())
+		require.NoError(t, err)
+
+		expected, err := StringifyVersionInfo(DefaultInfo())
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		commented := `{
+  // bumped by CI
+  "StringFileInfo": {
+    /* shown in the properties dialog */
+    "FileVersion": "1.0", // kept with its value
+    "ProductName": "Example" // last member
+  },
+}
+`
+		info, err := ParseVersionInfo([]byte(commented))
+		require.NoError(t, err)
+		info.StringFileInfo.FileVersion = "1.1"
+		info.StringFileInfo.CompanyName = "Example Corp"
+
+		result, err := PatchVersionInfo([]byte(commented), info)
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  // bumped by CI
+  "StringFileInfo": {
+    /* shown in the properties dialog */
+    "FileVersion": "1.1", // kept with its value
+    "ProductName": "Example", // last member
+    "CompanyName": "Example Corp"
+  },
+}
+`, string(result))
+
+		parsed, err := ParseVersionInfo(result)
+		require.NoError(t, err)
+		assert.Equal(t, info.StringFileInfo, parsed.StringFileInfo)
+	})
+
+	t.Run("comments in empty object and after last member", func(t *testing.T) {
+		commented := `{
+  "StringFileInfo": { /* none */ },
+  "VarFileInfo": {
+    "Translation": {
+      "LangID": 1033 /* why */
+    }
+  }
+}
+`
+		info, err := ParseVersionInfo([]byte(commented))
+		require.NoError(t, err)
+		info.StringFileInfo.ProductName = "Example"
+		info.VarFileInfo.Translation.CharsetID = 1200
+
+		result, err := PatchVersionInfo([]byte(commented), info)
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "StringFileInfo": { /* none */
+    "ProductName": "Example"
+  },
+  "VarFileInfo": {
+    "Translation": {
+      "LangID": 1033, /* why */
+      "CharsetID": 1200
+    }
+  }
+}
+`, string(result))
+
+		parsed, err := ParseVersionInfo(result)
+		require.NoError(t, err)
+		assert.Equal(t, info.StringFileInfo, parsed.StringFileInfo)
+		assert.Equal(t, info.VarFileInfo, parsed.VarFileInfo)
+	})
+
+	t.Run("invalid original", func(t *testing.T) {
+		_, err := PatchVersionInfo([]byte("{invalid json"), DefaultInfo())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,572 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/josephspurrier/goversioninfo"
+)
+
+var (
+	ErrNoVersionInfo         = errors.New("no VERSIONINFO resource found")
+	ErrInvalidResourceScript = errors.New("invalid resource script")
+)
+
+// rcFlag is a statement of a VERSIONINFO resource which sets a hexadecimal field of FixedFileInfo.
+// Width is the number of digits the field is written with, following DefaultInfo.
+type rcFlag struct {
+	statement string
+	width     int
+	field     func(info *goversioninfo.FixedFileInfo) *string
+}
+
+var rcFlags = []rcFlag{
+	{statement: "FILEFLAGSMASK", width: 2, field: func(info *goversioninfo.FixedFileInfo) *string { return &info.FileFlagsMask }},
+	{statement: "FILEFLAGS", width: 2, field: func(info *goversioninfo.FixedFileInfo) *string { return &info.FileFlags }},
+	{statement: "FILEOS", width: 6, field: func(info *goversioninfo.FixedFileInfo) *string { return &info.FileOS }},
+	{statement: "FILETYPE", width: 2, field: func(info *goversioninfo.FixedFileInfo) *string { return &info.FileType }},
+	{statement: "FILESUBTYPE", width: 2, field: func(info *goversioninfo.FixedFileInfo) *string { return &info.FileSubType }},
+}
+
+// rcConstants are the names of winver.h which resource scripts commonly use for the flags.
+var rcConstants = map[string]uint32{
+	"VS_FFI_FILEFLAGSMASK": 0x3f,
+	"VS_FF_DEBUG":          0x1,
+	"VS_FF_PRERELEASE":     0x2,
+	"VS_FF_PATCHED":        0x4,
+	"VS_FF_PRIVATEBUILD":   0x8,
+	"VS_FF_INFOINFERRED":   0x10,
+	"VS_FF_SPECIALBUILD":   0x20,
+	"VOS_UNKNOWN":          0x0,
+	"VOS_DOS":              0x10000,
+	"VOS_NT":               0x40000,
+	"VOS__WINDOWS16":       0x1,
+	"VOS__WINDOWS32":       0x4,
+	"VOS_DOS_WINDOWS16":    0x10001,
+	"VOS_DOS_WINDOWS32":    0x10004,
+	"VOS_NT_WINDOWS32":     0x40004,
+	"VFT_UNKNOWN":          0x0,
+	"VFT_APP":              0x1,
+	"VFT_DLL":              0x2,
+	"VFT_DRV":              0x3,
+	"VFT_FONT":             0x4,
+	"VFT_VXD":              0x5,
+	"VFT_STATIC_LIB":       0x7,
+	"VFT2_UNKNOWN":         0x0,
+}
+
+// StringifyResourceScript returns info as a VERSIONINFO resource script, which rc and windres compile into
+// the version resource goversioninfo builds. Empty StringFileInfo values are left out, and invalid hexadecimal flags
+// are written as 0 like goversioninfo does. IconPath and ManifestPath are not included.
+func StringifyResourceScript(info Info) []byte {
+	var result strings.Builder
+	fixed := info.FixedFileInfo
+	translation := info.VarFileInfo.Translation
+
+	result.WriteString("1 VERSIONINFO\n")
+	fmt.Fprintf(&result, "FILEVERSION %s\n", rcVersion(fixed.FileVersion))
+	fmt.Fprintf(&result, "PRODUCTVERSION %s\n", rcVersion(fixed.ProductVersion))
+	for _, flag := range rcFlags {
+		value, _ := strconv.ParseUint(*flag.field(&fixed), 16, 32)
+		fmt.Fprintf(&result, "%s 0x%x\n", flag.statement, value)
+	}
+
+	result.WriteString("BEGIN\n    BLOCK \"StringFileInfo\"\n    BEGIN\n")
+	fmt.Fprintf(&result, "        BLOCK \"%04X%04X\"\n        BEGIN\n", uint16(translation.LangID), uint16(translation.CharsetID))
+	for _, field := range StringFields {
+		if value := info.StringField(field); value != "" {
+			fmt.Fprintf(&result, "            VALUE \"%s\", %s\n", field, rcString(value))
+		}
+	}
+	result.WriteString("        END\n    END\n    BLOCK \"VarFileInfo\"\n    BEGIN\n")
+	fmt.Fprintf(&result, "        VALUE \"Translation\", 0x%04x, %d\n", uint16(translation.LangID), uint16(translation.CharsetID))
+	result.WriteString("    END\nEND\n")
+
+	return []byte(result.String())
+}
+
+func rcVersion(version goversioninfo.FileVersion) string {
+	return fmt.Sprintf("%d,%d,%d,%d", version.Major, version.Minor, version.Patch, version.Build)
+}
+
+// rcString returns value as a wide string literal. Characters other than printable ASCII are escaped
+// as UTF-16 code units, so that the script compiles the same under any code page.
+func rcString(value string) string {
+	var result strings.Builder
+	result.WriteString(`L"`)
+	for _, r := range value {
+		switch {
+		case r == '"':
+			result.WriteString(`""`)
+		case r == '\\':
+			result.WriteString(`\\`)
+		case r == '\n':
+			result.WriteString(`\n`)
+		case r == '\r':
+			result.WriteString(`\r`)
+		case r == '\t':
+			result.WriteString(`\t`)
+		case r < 0x20 || r > 0x7e:
+			units := []rune{r}
+			if r > 0xffff {
+				high, low := utf16.EncodeRune(r)
+				units = []rune{high, low}
+			}
+			for _, unit := range units {
+				fmt.Fprintf(&result, `\x%04x`, unit)
+			}
+		default:
+			result.WriteRune(r)
+		}
+	}
+	result.WriteByte('"')
+	return result.String()
+}
+
+// ParseResourceScript returns the info in the first VERSIONINFO resource of data, which is a resource script of rc or windres.
+// Other resources, comments and preprocessor lines are skipped, so the last of statements in #ifdef branches wins.
+// Ignored names the values which Info can not keep, such as StringFileInfo values other than StringFields
+// and string tables after the first. Malformed data is a ParseError.
+func ParseResourceScript(data []byte) (info Info, ignored []string, err error) {
+	tokens, err := scanResourceScript(data)
+	if err != nil {
+		return Info{}, nil, err
+	}
+
+	p := &rcParser{data: data, tokens: tokens}
+	for p.index < len(tokens) && !p.keyword("VERSIONINFO") {
+		p.index++
+	}
+	if p.index == len(tokens) {
+		return Info{}, nil, ErrNoVersionInfo
+	}
+	p.index++
+
+	if err = p.fixedFileInfo(&info); err != nil {
+		return Info{}, nil, err
+	}
+	if err = p.blocks(&info, &ignored); err != nil {
+		return Info{}, nil, err
+	}
+	return info, ignored, nil
+}
+
+// rcToken is a word, a number or a punctuation of a resource script, or the decoded value of a string.
+type rcToken struct {
+	text     string
+	isString bool
+	offset   int
+}
+
+func scanResourceScript(data []byte) (result []rcToken, err error) {
+	lineStart := true
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '\n':
+			lineStart = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case c == '#' && lineStart:
+			//전처리 줄은 \로 끝나면 다음 줄까지 이어짐
+			for i < len(data) && (data[i] != '\n' || data[i-1] == '\\') {
+				i++
+			}
+			continue
+		case bytes.HasPrefix(data[i:], []byte("//")):
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			continue
+		case bytes.HasPrefix(data[i:], []byte("/*")):
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, parseErrorAt(data, i, fmt.Errorf("%w: unterminated comment", ErrInvalidResourceScript))
+			}
+			i += end + 4
+			continue
+		}
+		lineStart = false
+
+		start := i
+		switch {
+		case c == '"' || (c == 'L' || c == 'l') && i+1 < len(data) && data[i+1] == '"':
+			var value string
+			if value, i, err = rcStringAt(data, i+bytes.IndexByte(data[i:], '"')); err != nil {
+				return nil, err
+			}
+			result = append(result, rcToken{text: value, isString: true, offset: start})
+		case isRCWordByte(c):
+			for i < len(data) && isRCWordByte(data[i]) {
+				i++
+			}
+			result = append(result, rcToken{text: string(data[start:i]), offset: start})
+		default:
+			i++
+			result = append(result, rcToken{text: string(c), offset: start})
+		}
+	}
+	return result, nil
+}
+
+func isRCWordByte(c byte) bool {
+	return c == '_' || c == '.' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// rcStringAt decodes the string whose opening quote is at start, returning the offset after its closing quote.
+// A quote is written twice in it, and escape sequences are those of C.
+func rcStringAt(data []byte, start int) (string, int, error) {
+	var result strings.Builder
+	//\x로 쓴 UTF-16 서로게이트 쌍은 합쳐서 한 글자로 씀
+	high := rune(0)
+	for i := start + 1; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '"' && i+1 < len(data) && data[i+1] == '"':
+			result.WriteByte('"')
+			i++
+		case c == '"':
+			return result.String(), i + 1, nil
+		case c == '\\' && i+1 < len(data):
+			i++
+			switch escaped := data[i]; escaped {
+			case 'n':
+				result.WriteByte('\n')
+			case 'r':
+				result.WriteByte('\r')
+			case 't':
+				result.WriteByte('\t')
+			case 'a':
+				result.WriteByte('\a')
+			case '0':
+				result.WriteByte(0)
+			case 'x', 'X':
+				end := i + 1
+				for end < len(data) && end < i+5 && strings.IndexByte("0123456789abcdefABCDEF", data[end]) >= 0 {
+					end++
+				}
+				code, err := strconv.ParseUint(string(data[i+1:end]), 16, 16)
+				if err != nil {
+					return "", 0, parseErrorAt(data, i-1, fmt.Errorf("%w: invalid escape sequence", ErrInvalidResourceScript))
+				}
+				switch unit := rune(code); {
+				case high != 0:
+					result.WriteRune(utf16.DecodeRune(high, unit))
+					high = 0
+				case 0xd800 <= unit && unit < 0xdc00:
+					high = unit
+				default:
+					result.WriteRune(unit)
+				}
+				i = end - 1
+			default:
+				result.WriteByte(escaped)
+			}
+		default:
+			result.WriteByte(c)
+		}
+	}
+	return "", 0, parseErrorAt(data, start, fmt.Errorf("%w: unterminated string", ErrInvalidResourceScript))
+}
+
+type rcParser struct {
+	data   []byte
+	tokens []rcToken
+	index  int
+}
+
+func (p *rcParser) errorf(format string, args ...any) error {
+	offset := len(p.data)
+	if p.index < len(p.tokens) {
+		offset = p.tokens[p.index].offset
+	}
+	return parseErrorAt(p.data, offset, fmt.Errorf("%w: %s", ErrInvalidResourceScript, fmt.Sprintf(format, args...)))
+}
+
+// keyword reports whether the current token is one of words, ignoring case as rc does.
+func (p *rcParser) keyword(words ...string) bool {
+	if p.index >= len(p.tokens) || p.tokens[p.index].isString {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(p.tokens[p.index].text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *rcParser) expect(words ...string) error {
+	if !p.keyword(words...) {
+		return p.errorf("expected %s", strings.Join(words, " or "))
+	}
+	p.index++
+	return nil
+}
+
+func (p *rcParser) begin() error {
+	return p.expect("BEGIN", "{")
+}
+
+func (p *rcParser) end() bool {
+	if p.keyword("END", "}") {
+		p.index++
+		return true
+	}
+	return false
+}
+
+// skipComma skips an optional comma, as rc allows values to be separated by spaces too.
+func (p *rcParser) skipComma() {
+	if p.keyword(",") {
+		p.index++
+	}
+}
+
+func (p *rcParser) string() (string, error) {
+	if p.index >= len(p.tokens) || !p.tokens[p.index].isString {
+		return "", p.errorf("expected string")
+	}
+	p.index++
+	return p.tokens[p.index-1].text, nil
+}
+
+// number reads a number, a constant of winver.h or their combination by |.
+func (p *rcParser) number() (result uint32, err error) {
+	for {
+		if p.index >= len(p.tokens) || p.tokens[p.index].isString {
+			return 0, p.errorf("expected number")
+		}
+
+		text := p.tokens[p.index].text
+		value, ok := rcConstants[strings.ToUpper(text)]
+		if !ok {
+			parsed, err := strconv.ParseUint(strings.TrimRight(text, "lLuU"), 0, 32)
+			if err != nil {
+				return 0, p.errorf("unknown value %s", text)
+			}
+			value = uint32(parsed)
+		}
+		result |= value
+		p.index++
+
+		if !p.keyword("|") {
+			return result, nil
+		}
+		p.index++
+	}
+}
+
+// numbers reads the numbers separated by commas.
+func (p *rcParser) numbers() (result []uint32, err error) {
+	for {
+		value, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		if !p.keyword(",") {
+			return result, nil
+		}
+		p.index++
+	}
+}
+
+func (p *rcParser) version() (result goversioninfo.FileVersion, err error) {
+	values, err := p.numbers()
+	if err != nil {
+		return result, err
+	}
+	values = append(values, 0, 0, 0)
+	return goversioninfo.FileVersion{Major: int(values[0]), Minor: int(values[1]), Patch: int(values[2]), Build: int(values[3])}, nil
+}
+
+// fixedFileInfo reads the statements between VERSIONINFO and its BEGIN.
+func (p *rcParser) fixedFileInfo(info *Info) (err error) {
+	fixed := &info.FixedFileInfo
+	for !p.keyword("BEGIN", "{") {
+		switch {
+		case p.index >= len(p.tokens):
+			return p.errorf("expected BEGIN")
+		case p.keyword("FILEVERSION"):
+			p.index++
+			fixed.FileVersion, err = p.version()
+		case p.keyword("PRODUCTVERSION"):
+			p.index++
+			fixed.ProductVersion, err = p.version()
+		default:
+			err = p.flag(fixed)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *rcParser) flag(fixed *goversioninfo.FixedFileInfo) error {
+	for _, flag := range rcFlags {
+		if p.keyword(flag.statement) {
+			p.index++
+			value, err := p.number()
+			if err != nil {
+				return err
+			}
+			*flag.field(fixed) = fmt.Sprintf("%0*x", flag.width, value)
+			return nil
+		}
+	}
+	return p.errorf("unknown statement %s", p.tokens[p.index].text)
+}
+
+// blocks reads the body of VERSIONINFO from its BEGIN.
+func (p *rcParser) blocks(info *Info, ignored *[]string) error {
+	if err := p.begin(); err != nil {
+		return err
+	}
+
+	hasTranslation := false
+	for !p.end() {
+		if err := p.expect("BLOCK"); err != nil {
+			return err
+		}
+		name, err := p.string()
+		if err != nil {
+			return err
+		}
+		if err = p.begin(); err != nil {
+			return err
+		}
+
+		switch {
+		case strings.EqualFold(name, "StringFileInfo"):
+			err = p.stringFileInfo(info, !hasTranslation, ignored)
+		case strings.EqualFold(name, "VarFileInfo"):
+			hasTranslation = true
+			err = p.varFileInfo(info, ignored)
+		default:
+			*ignored = append(*ignored, name)
+			err = p.skipBlock()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringFileInfo reads the string tables after BEGIN of StringFileInfo, which keeps only the first one.
+// The translation is taken from the name of the table unless VarFileInfo is read before.
+func (p *rcParser) stringFileInfo(info *Info, translate bool, ignored *[]string) error {
+	for tables := 0; !p.end(); tables++ {
+		if err := p.expect("BLOCK"); err != nil {
+			return err
+		}
+		name, err := p.string()
+		if err != nil {
+			return err
+		}
+		if err = p.begin(); err != nil {
+			return err
+		}
+
+		if tables > 0 {
+			*ignored = append(*ignored, stringFileInfoFieldPrefix+name)
+			if err = p.skipBlock(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if language, err := strconv.ParseUint(name, 16, 32); translate && err == nil && len(name) == 8 {
+			info.VarFileInfo.Translation.LangID = goversioninfo.LangID(language >> 16)
+			info.VarFileInfo.Translation.CharsetID = goversioninfo.CharsetID(language & 0xffff)
+		}
+		if err = p.stringTable(info, ignored); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *rcParser) stringTable(info *Info, ignored *[]string) error {
+	for !p.end() {
+		if err := p.expect("VALUE"); err != nil {
+			return err
+		}
+		key, err := p.string()
+		if err != nil {
+			return err
+		}
+		p.skipComma()
+
+		//인접한 문자열은 이어 붙이고, 예전 스크립트가 붙이던 \0은 뺌
+		var value strings.Builder
+		for p.index < len(p.tokens) && p.tokens[p.index].isString {
+			value.WriteString(p.tokens[p.index].text)
+			p.index++
+		}
+		if value.Len() == 0 {
+			return p.errorf("expected string")
+		}
+
+		field, err := ParseStringField(key)
+		if err != nil {
+			*ignored = append(*ignored, stringFileInfoFieldPrefix+key)
+			continue
+		}
+		*stringFieldOf(&info.StringFileInfo, field) = strings.TrimRight(value.String(), "\x00")
+	}
+	return nil
+}
+
+func (p *rcParser) varFileInfo(info *Info, ignored *[]string) error {
+	for !p.end() {
+		if err := p.expect("VALUE"); err != nil {
+			return err
+		}
+		key, err := p.string()
+		if err != nil {
+			return err
+		}
+		p.skipComma()
+		values, err := p.numbers()
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(key, "Translation") || len(values) < 2 {
+			*ignored = append(*ignored, "VarFileInfo."+key)
+			continue
+		}
+		for i := 2; i+1 < len(values); i += 2 {
+			*ignored = append(*ignored, fmt.Sprintf("VarFileInfo.Translation %04X%04X", values[i], values[i+1]))
+		}
+		info.VarFileInfo.Translation.LangID = goversioninfo.LangID(values[0])
+		info.VarFileInfo.Translation.CharsetID = goversioninfo.CharsetID(values[1])
+	}
+	return nil
+}
+
+// skipBlock skips tokens after BEGIN until its END.
+func (p *rcParser) skipBlock() error {
+	for depth := 1; depth > 0; {
+		switch {
+		case p.index >= len(p.tokens):
+			return p.errorf("expected END")
+		case p.keyword("BEGIN", "{"):
+			depth++
+		case p.keyword("END", "}"):
+			depth--
+		}
+		p.index++
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringifyResourceScript(t *testing.T) {
+	info := DefaultInfo().VersionUpdated(Version{Major: 1, Minor: 2, Patch: 3, Build: 4}, Version{Major: 1, Minor: 2}, TargetBoth, NotationDetail)
+	info.StringFileInfo.CompanyName = "Example \"Corp\" ©"
+	info.StringFileInfo.Comments = "C:\\path\nnext"
+
+	assert.Equal(t, `1 VERSIONINFO
+FILEVERSION 1,2,3,4
+PRODUCTVERSION 1,2,0,0
+FILEFLAGSMASK 0x3f
+FILEFLAGS 0x0
+FILEOS 0x40004
+FILETYPE 0x1
+FILESUBTYPE 0x0
+BEGIN
+    BLOCK "StringFileInfo"
+    BEGIN
+        BLOCK "040904B0"
+        BEGIN
+            VALUE "Comments", L"C:\\path\nnext"
+            VALUE "CompanyName", L"Example ""Corp"" \x00a9"
+            VALUE "FileVersion", L"1.2.3.4"
+            VALUE "ProductVersion", L"1.2.0.0"
+        END
+    END
+    BLOCK "VarFileInfo"
+    BEGIN
+        VALUE "Translation", 0x0409, 1200
+    END
+END
+`, string(StringifyResourceScript(info)))
+}
+
+func TestParseResourceScript(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		info := DefaultInfo().VersionUpdated(Version{Major: 1, Minor: 2, Patch: 3, Build: 4}, Version{Major: 2}, TargetBoth, NotationDetail)
+		info.StringFileInfo.CompanyName = "Example \"Corp\" © 🚀"
+		info.StringFileInfo.Comments = "C:\\path\nnext"
+
+		parsed, ignored, err := ParseResourceScript(StringifyResourceScript(info))
+		require.NoError(t, err)
+		assert.Empty(t, ignored)
+		assert.Equal(t, info, parsed)
+	})
+
+	t.Run("generated by Visual Studio", func(t *testing.T) {
+		script := `// Microsoft Visual C++ generated resource script.
+#include "resource.h"
+#define APSTUDIO_READONLY_SYMBOLS
+IDI_ICON1               ICON                    "app.ico"
+
+VS_VERSION_INFO VERSIONINFO
+ FILEVERSION 1,0,0,1
+ PRODUCTVERSION 1,0
+ FILEFLAGSMASK VS_FFI_FILEFLAGSMASK
+#ifdef _DEBUG
+ FILEFLAGS VS_FF_DEBUG | VS_FF_PRERELEASE
+#else
+ FILEFLAGS 0x0L
+#endif
+ FILEOS VOS_NT_WINDOWS32
+ FILETYPE VFT_DLL
+ FILESUBTYPE 0x0L
+BEGIN
+    BLOCK "StringFileInfo"
+    BEGIN
+        BLOCK "041204b0"
+        BEGIN
+            VALUE "CompanyName", L"Example Corp\0"
+            VALUE "FileVersion", "1, 0, 0, 1" "\0"
+            VALUE "X-Custom", "dropped"
+        END
+        BLOCK "040904b0"
+        BEGIN
+            VALUE "CompanyName", "Other"
+        END
+    END
+    BLOCK "VarFileInfo"
+    {
+        VALUE "Translation", 0x412, 1200, 0x409, 1200
+    }
+END
+`
+		info, ignored, err := ParseResourceScript([]byte(script))
+		require.NoError(t, err)
+		assert.Equal(t, 1, info.FixedFileInfo.FileVersion.Major)
+		assert.Equal(t, 1, info.FixedFileInfo.FileVersion.Build)
+		assert.Equal(t, 1, info.FixedFileInfo.ProductVersion.Major)
+		assert.Equal(t, 0, info.FixedFileInfo.ProductVersion.Build)
+		assert.Equal(t, "3f", info.FixedFileInfo.FileFlagsMask)
+		assert.Equal(t, "00", info.FixedFileInfo.FileFlags)
+		assert.Equal(t, "040004", info.FixedFileInfo.FileOS)
+		assert.Equal(t, "02", info.FixedFileInfo.FileType)
+		assert.Equal(t, "Example Corp", info.StringFileInfo.CompanyName)
+		assert.Equal(t, "1, 0, 0, 1", info.StringFileInfo.FileVersion)
+		assert.EqualValues(t, 0x412, info.VarFileInfo.Translation.LangID)
+		assert.EqualValues(t, 1200, info.VarFileInfo.Translation.CharsetID)
+		assert.Equal(t, []string{"StringFileInfo.X-Custom", "StringFileInfo.040904b0", "VarFileInfo.Translation 040904B0"}, ignored)
+	})
+
+	t.Run("translation from string table", func(t *testing.T) {
+		script := "1 VERSIONINFO\nBEGIN\nBLOCK \"StringFileInfo\"\nBEGIN\nBLOCK \"041104E4\"\nBEGIN\nEND\nEND\nEND\n"
+		info, _, err := ParseResourceScript([]byte(script))
+		require.NoError(t, err)
+		assert.EqualValues(t, 0x411, info.VarFileInfo.Translation.LangID)
+		assert.EqualValues(t, 1252, info.VarFileInfo.Translation.CharsetID)
+	})
+
+	t.Run("no VERSIONINFO", func(t *testing.T) {
+		_, _, err := ParseResourceScript([]byte("IDI_ICON1 ICON \"app.ico\"\n// VERSIONINFO\n"))
+		assert.ErrorIs(t, err, ErrNoVersionInfo)
+	})
+
+	invalid := []struct {
+		name   string
+		script string
+		line   int
+	}{
+		{name: "unknown statement", script: "1 VERSIONINFO\nFILEVERSION 1,0,0,0\nFILEDATE 0\nBEGIN\nEND\n", line: 3},
+		{name: "unknown constant", script: "1 VERSIONINFO\nFILEFLAGS MY_FLAGS\nBEGIN\nEND\n", line: 2},
+		{name: "missing END", script: "1 VERSIONINFO\nBEGIN\nBLOCK \"StringFileInfo\"\nBEGIN\n", line: 5},
+		{name: "unterminated string", script: "1 VERSIONINFO\nBEGIN\nBLOCK \"StringFileInfo\nEND\n", line: 3},
+		{name: "number as string value", script: "1 VERSIONINFO\nBEGIN\nBLOCK \"StringFileInfo\"\nBEGIN\nBLOCK \"040904B0\"\nBEGIN\nVALUE \"Comments\", 1\n", line: 7},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseResourceScript([]byte(tt.script))
+			var parseErr ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.ErrorIs(t, err, ErrInvalidResourceScript)
+			assert.Equal(t, tt.line, parseErr.Line)
+		})
+	}
+}
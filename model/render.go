@@ -0,0 +1,94 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrUndefinedVariable = errors.New("undefined variable")
+)
+
+// RenderContext is what the templates in StringFileInfo values can reference. Vars and the StringFileInfo values
+// as written are referenced by name, e.g. {{.CompanyName}}, besides {{.Company}} and {{.Product}} for CompanyName and
+// ProductName, {{.Year}} and {{.Date}}.
+// Env looks up environment variables for {{env "NAME"}}, and no variable is defined when it is nil.
+type RenderContext struct {
+	Vars map[string]string
+	Date time.Time
+	Env  func(key string) (string, bool)
+}
+
+func (c RenderContext) data(i Info) map[string]any {
+	result := make(map[string]any, len(StringFields)+len(c.Vars)+4)
+	for _, field := range StringFields {
+		result[string(field)] = i.StringField(field)
+	}
+	//CompanyName과 ProductName은 짧은 이름으로도 참조할 수 있음
+	result["Company"] = i.StringFileInfo.CompanyName
+	result["Product"] = i.StringFileInfo.ProductName
+	result["Year"] = c.Date.Year()
+	result["Date"] = c.Date
+	for key, value := range c.Vars {
+		result[key] = value
+	}
+	return result
+}
+
+func (c RenderContext) env(key string) (string, error) {
+	if c.Env != nil {
+		if value, ok := c.Env(key); ok {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: environment variable %s is not set", ErrUndefinedVariable, key)
+}
+
+// undefinedKeyOf returns the key of the error text/template gives with missingkey=error, which is not wrapped in any error type.
+func undefinedKeyOf(err error) (string, bool) {
+	_, key, found := strings.Cut(err.Error(), "map has no entry for key ")
+	return key, found
+}
+
+func renderValue(field StringField, value string, data map[string]any, funcs template.FuncMap) (string, error) {
+	t, err := template.New(string(field)).Option("missingkey=error").Funcs(funcs).Parse(value)
+	if err != nil {
+		return value, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	var result bytes.Buffer
+	if err = t.Execute(&result, data); err != nil {
+		if key, ok := undefinedKeyOf(err); ok {
+			return value, fmt.Errorf("%w: %s", ErrUndefinedVariable, key)
+		}
+		//env의 오류는 ExecError에 감싸져 있음
+		if errors.Is(err, ErrUndefinedVariable) {
+			return value, err
+		}
+		return value, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return result.String(), nil
+}
+
+// Rendered executes every StringFileInfo value as a text/template, so that the template source can be kept
+// while the rendered info is given to goversioninfo. An undefined variable is an error rather than being left empty.
+func (i Info) Rendered(context RenderContext) (result Info, err error) {
+	result = i
+	data := context.data(i)
+	funcs := template.FuncMap{"env": context.env}
+
+	for _, field := range StringFields {
+		value, err := renderValue(field, i.StringField(field), data, funcs)
+		if err != nil {
+			return i, RenderError{Field: field, Err: err}
+		}
+		if result, err = result.StringFieldUpdated(field, value); err != nil {
+			return i, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoRendered(t *testing.T) {
+	info := Info{}
+	info.StringFileInfo.CompanyName = "Example Corp"
+	info.StringFileInfo.LegalCopyright = "Copyright © {{.Year}} {{.CompanyName}}"
+	info.StringFileInfo.Comments = `Built from {{env "CI_COMMIT_SHA"}} on {{.Date.Format "2006-01-02"}}`
+	info.StringFileInfo.ProductName = "Example {{.Channel}}"
+
+	env := map[string]string{"CI_COMMIT_SHA": "abc1234"}
+	context := RenderContext{
+		Vars: map[string]string{"Channel": "beta"},
+		Date: time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Env: func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		},
+	}
+
+	result, err := info.Rendered(context)
+	require.NoError(t, err)
+	assert.Equal(t, "Copyright © 2024 Example Corp", result.StringFileInfo.LegalCopyright)
+	assert.Equal(t, "Built from abc1234 on 2024-03-05", result.StringFileInfo.Comments)
+	assert.Equal(t, "Example beta", result.StringFileInfo.ProductName)
+	assert.Equal(t, "Example Corp", result.StringFileInfo.CompanyName)
+	assert.Equal(t, "Example {{.Channel}}", info.StringFileInfo.ProductName)
+
+	t.Run("undefined variable", func(t *testing.T) {
+		_, err := info.Rendered(RenderContext{Env: context.Env})
+		assert.ErrorIs(t, err, ErrUndefinedVariable)
+		assert.ErrorContains(t, err, "StringFileInfo.ProductName")
+		assert.ErrorContains(t, err, "Channel")
+	})
+
+	t.Run("short names", func(t *testing.T) {
+		info := Info{}
+		info.StringFileInfo.CompanyName = "Example Corp"
+		info.StringFileInfo.ProductName = "Example"
+		info.StringFileInfo.LegalCopyright = "Copyright © {{.Year}} {{.Company}}"
+		info.StringFileInfo.FileDescription = "{{.Product}} client"
+
+		result, err := info.Rendered(context)
+		require.NoError(t, err)
+		assert.Equal(t, "Copyright © 2024 Example Corp", result.StringFileInfo.LegalCopyright)
+		assert.Equal(t, "Example client", result.StringFileInfo.FileDescription)
+	})
+
+	t.Run("undefined environment variable", func(t *testing.T) {
+		_, err := info.Rendered(RenderContext{Vars: context.Vars})
+		var renderErr RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Equal(t, FieldComments, renderErr.Field)
+		assert.ErrorIs(t, err, ErrUndefinedVariable)
+		assert.ErrorContains(t, err, "CI_COMMIT_SHA")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		info := Info{}
+		info.StringFileInfo.Comments = "{{.Year"
+		_, err := info.Rendered(context)
+		assert.ErrorIs(t, err, ErrInvalidTemplate)
+	})
+}
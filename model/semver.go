@@ -0,0 +1,186 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidSemVer = errors.New("invalid semantic version")
+)
+
+// SemVer is a Semantic Versioning 2.0.0 version such as 1.4.0-rc.2+sha.abc123.
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Metadata   []string
+}
+
+func ParseSemVer(versionString string) (result SemVer, err error) {
+	core, metadata, hasMetadata := strings.Cut(versionString, "+")
+	core, preRelease, hasPreRelease := strings.Cut(core, "-")
+
+	separated := strings.Split(core, ".")
+	if len(separated) != 3 {
+		err = ErrInvalidSemVer
+		return
+	}
+
+	numbers := make([]int, 3)
+	for i, element := range separated {
+		if !isNumericIdentifier(element) {
+			err = ErrInvalidSemVer
+			return
+		}
+		if numbers[i], err = strconv.Atoi(element); err != nil {
+			return
+		}
+	}
+	result.Major, result.Minor, result.Patch = numbers[0], numbers[1], numbers[2]
+
+	if hasPreRelease {
+		if result.PreRelease, err = parseIdentifiers(preRelease, true); err != nil {
+			return SemVer{}, err
+		}
+	}
+	if hasMetadata {
+		if result.Metadata, err = parseIdentifiers(metadata, false); err != nil {
+			return SemVer{}, err
+		}
+	}
+	return
+}
+
+func parseIdentifiers(value string, strictNumeric bool) ([]string, error) {
+	identifiers := strings.Split(value, ".")
+	for _, identifier := range identifiers {
+		if identifier == "" || strings.Trim(identifier, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+			return nil, ErrInvalidSemVer
+		}
+		//숫자로만 이루어진 pre-release 식별자는 0으로 시작할 수 없음
+		if strictNumeric && isDigits(identifier) && !isNumericIdentifier(identifier) {
+			return nil, ErrInvalidSemVer
+		}
+	}
+	return identifiers, nil
+}
+
+func isDigits(value string) bool {
+	return value != "" && strings.Trim(value, "0123456789") == ""
+}
+
+func isNumericIdentifier(value string) bool {
+	return isDigits(value) && (value == "0" || value[0] != '0')
+}
+
+func (s SemVer) IsPreRelease() bool {
+	return len(s.PreRelease) > 0
+}
+
+// Updated returns the version bumped at the given level.
+// A non-empty preRelease starts a new pre-release line (e.g. rc.1) on the bumped version.
+// LevelPreRelease increments the trailing counter of the current pre-release, LevelRelease promotes it to final,
+// and LevelBuild has no SemVer counterpart, so the version is returned unchanged.
+func (s SemVer) Updated(level VersionLevel, preRelease string) SemVer {
+	result := SemVer{Major: s.Major, Minor: s.Minor, Patch: s.Patch}
+	switch level {
+	case LevelMajor:
+		result.Major += 1
+		result.Minor = 0
+		result.Patch = 0
+	case LevelMinor:
+		result.Minor += 1
+		result.Patch = 0
+	case LevelPatch:
+		result.Patch += 1
+	case LevelPreRelease:
+		switch {
+		case s.IsPreRelease() && (preRelease == "" || s.PreRelease[0] == preRelease):
+			result.PreRelease = incrementedIdentifiers(s.PreRelease)
+			return result
+		case !s.IsPreRelease():
+			result.Patch += 1
+		}
+	case LevelRelease:
+		return result
+	default:
+		return s
+	}
+
+	if preRelease != "" {
+		result.PreRelease = []string{preRelease, "1"}
+	} else if level == LevelPreRelease {
+		result.PreRelease = []string{"1"}
+	}
+	return result
+}
+
+func incrementedIdentifiers(identifiers []string) []string {
+	result := append([]string{}, identifiers...)
+	last := len(result) - 1
+	if !isDigits(result[last]) {
+		return append(result, "1")
+	}
+	counter, _ := strconv.Atoi(result[last])
+	result[last] = strconv.Itoa(counter + 1)
+	return result
+}
+
+func (s SemVer) WithMetadata(metadata string) (SemVer, error) {
+	result := s
+	result.Metadata = nil
+	if metadata == "" {
+		return result, nil
+	}
+
+	identifiers, err := parseIdentifiers(metadata, false)
+	if err != nil {
+		return s, err
+	}
+	result.Metadata = identifiers
+	return result, nil
+}
+
+// Version maps the SemVer onto the four integers of FixedFileInfo.
+// Build carries the trailing numeric identifier of the pre-release, or the largest component for final releases,
+// so that 1.4.0 (1.4.0.65535) is newer than 1.4.0-rc.2 (1.4.0.2) for installers comparing FILEVERSION.
+// The labels of pre-releases are dropped, so the order of SemVer is kept only while the trailing number keeps growing
+// across them, e.g. 1.4.0-beta.3 (1.4.0.3) is above 1.4.0-rc.1 (1.4.0.1), while 1.4.0-rc.4 would follow it.
+func (s SemVer) Version() Version {
+	result := Version{Major: s.Major, Minor: s.Minor, Patch: s.Patch, Build: maxVersionComponent}
+	if s.IsPreRelease() {
+		result.Build = 0
+		if last := s.PreRelease[len(s.PreRelease)-1]; isDigits(last) {
+			result.Build, _ = strconv.Atoi(last)
+		}
+	}
+	return result
+}
+
+// CheckRange reports ErrVersionOutOfRange when the Version of the SemVer does not fit in FixedFileInfo,
+// or when the pre-release counter reaches the build of final releases.
+func (s SemVer) CheckRange() error {
+	version := s.Version()
+	if err := version.CheckRange(); err != nil {
+		return err
+	}
+	if s.IsPreRelease() && version.Build == maxVersionComponent {
+		return fmt.Errorf("%w: pre-release counter of %s must be below %d, which is the build of final releases", ErrVersionOutOfRange, s, maxVersionComponent)
+	}
+	return nil
+}
+
+func (s SemVer) String() string {
+	result := s.Version().String(NotationNormal)
+	if s.IsPreRelease() {
+		result += "-" + strings.Join(s.PreRelease, ".")
+	}
+	if len(s.Metadata) > 0 {
+		result += "+" + strings.Join(s.Metadata, ".")
+	}
+	return result
+}
@@ -0,0 +1,252 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected SemVer
+		hasError bool
+	}{
+		{
+			name:     "release",
+			input:    "1.4.0",
+			expected: SemVer{Major: 1, Minor: 4, Patch: 0},
+		},
+		{
+			name:     "pre-release",
+			input:    "1.4.0-rc.2",
+			expected: SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "2"}},
+		},
+		{
+			name:     "pre-release with metadata",
+			input:    "1.4.0-rc.2+sha.abc123",
+			expected: SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "2"}, Metadata: []string{"sha", "abc123"}},
+		},
+		{
+			name:     "hyphen inside pre-release",
+			input:    "1.0.0-x-y.1",
+			expected: SemVer{Major: 1, PreRelease: []string{"x-y", "1"}},
+		},
+		{
+			name:     "metadata only",
+			input:    "1.0.0+001",
+			expected: SemVer{Major: 1, Metadata: []string{"001"}},
+		},
+		{
+			name:     "missing patch",
+			input:    "1.4",
+			hasError: true,
+		},
+		{
+			name:     "four parts",
+			input:    "1.2.3.4",
+			hasError: true,
+		},
+		{
+			name:     "leading zero",
+			input:    "01.2.3",
+			hasError: true,
+		},
+		{
+			name:     "leading zero in numeric pre-release",
+			input:    "1.2.3-rc.01",
+			hasError: true,
+		},
+		{
+			name:     "empty identifier",
+			input:    "1.2.3-rc..1",
+			hasError: true,
+		},
+		{
+			name:     "invalid character",
+			input:    "1.2.3+sha_1",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSemVer(tt.input)
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, tt.input, result.String())
+			}
+		})
+	}
+}
+
+func TestSemVerUpdated(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		level      VersionLevel
+		preRelease string
+		expected   string
+	}{
+		{
+			name:     "major update",
+			version:  "1.2.3+sha.abc",
+			level:    LevelMajor,
+			expected: "2.0.0",
+		},
+		{
+			name:     "minor update",
+			version:  "1.2.3",
+			level:    LevelMinor,
+			expected: "1.3.0",
+		},
+		{
+			name:     "patch update",
+			version:  "1.2.3-rc.1",
+			level:    LevelPatch,
+			expected: "1.2.4",
+		},
+		{
+			name:       "minor update starting release candidate",
+			version:    "1.3.2",
+			level:      LevelMinor,
+			preRelease: "rc",
+			expected:   "1.4.0-rc.1",
+		},
+		{
+			name:     "pre-release counter",
+			version:  "1.4.0-rc.1",
+			level:    LevelPreRelease,
+			expected: "1.4.0-rc.2",
+		},
+		{
+			name:       "pre-release counter with same identifier",
+			version:    "1.4.0-rc.9",
+			level:      LevelPreRelease,
+			preRelease: "rc",
+			expected:   "1.4.0-rc.10",
+		},
+		{
+			name:     "pre-release without counter",
+			version:  "1.4.0-beta",
+			level:    LevelPreRelease,
+			expected: "1.4.0-beta.1",
+		},
+		{
+			name:       "pre-release switching identifier",
+			version:    "1.4.0-beta.3",
+			level:      LevelPreRelease,
+			preRelease: "rc",
+			expected:   "1.4.0-rc.1",
+		},
+		{
+			name:       "pre-release from release",
+			version:    "1.4.0",
+			level:      LevelPreRelease,
+			preRelease: "rc",
+			expected:   "1.4.1-rc.1",
+		},
+		{
+			name:     "numeric pre-release from release",
+			version:  "1.4.0",
+			level:    LevelPreRelease,
+			expected: "1.4.1-1",
+		},
+		{
+			name:     "promotion to release",
+			version:  "1.4.0-rc.2+sha.abc",
+			level:    LevelRelease,
+			expected: "1.4.0",
+		},
+		{
+			name:     "build level",
+			version:  "1.4.0-rc.2",
+			level:    LevelBuild,
+			expected: "1.4.0-rc.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseSemVer(tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version.Updated(tt.level, tt.preRelease).String())
+		})
+	}
+}
+
+func TestSemVerUpdatedDoesNotShareIdentifiers(t *testing.T) {
+	version := SemVer{Major: 1, PreRelease: []string{"rc", "1"}}
+
+	version.Updated(LevelPreRelease, "")
+
+	assert.Equal(t, []string{"rc", "1"}, version.PreRelease)
+}
+
+func TestSemVerWithMetadata(t *testing.T) {
+	version := SemVer{Major: 1, Minor: 4, PreRelease: []string{"rc", "2"}}
+
+	result, err := version.WithMetadata("sha.abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0-rc.2+sha.abc123", result.String())
+
+	result, err = result.WithMetadata("")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0-rc.2", result.String())
+
+	_, err = version.WithMetadata("sha..abc")
+	assert.ErrorIs(t, err, ErrInvalidSemVer)
+}
+
+func TestSemVerVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  SemVer
+		expected Version
+	}{
+		{
+			name:     "release",
+			version:  SemVer{Major: 1, Minor: 4, Patch: 0},
+			expected: Version{Major: 1, Minor: 4, Patch: 0, Build: 65535},
+		},
+		{
+			name:     "numbered pre-release",
+			version:  SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "2"}, Metadata: []string{"sha", "7"}},
+			expected: Version{Major: 1, Minor: 4, Patch: 0, Build: 2},
+		},
+		{
+			name:     "unnumbered pre-release",
+			version:  SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"beta"}},
+			expected: Version{Major: 1, Minor: 4, Patch: 0, Build: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.version.Version())
+		})
+	}
+}
+
+func TestSemVerVersionOrder(t *testing.T) {
+	//FILEVERSION을 비교하는 설치 프로그램이 정식 릴리스를 RC보다 새 버전으로 보아야 함
+	ordered := []string{"1.3.9", "1.4.0-beta", "1.4.0-rc.1", "1.4.0-rc.2", "1.4.0", "1.4.1-rc.1", "1.4.1"}
+	for i := 1; i < len(ordered); i++ {
+		older, err := ParseSemVer(ordered[i-1])
+		require.NoError(t, err)
+		newer, err := ParseSemVer(ordered[i])
+		require.NoError(t, err)
+		assert.Equal(t, -1, older.Version().Compare(newer.Version()), "%s < %s", older, newer)
+	}
+}
+
+func TestSemVerCheckRange(t *testing.T) {
+	assert.NoError(t, SemVer{Major: 1, PreRelease: []string{"rc", "65534"}}.CheckRange())
+	assert.ErrorIs(t, SemVer{Major: 1, PreRelease: []string{"rc", "65535"}}.CheckRange(), ErrVersionOutOfRange)
+	assert.ErrorIs(t, SemVer{Major: 70000}.CheckRange(), ErrVersionOutOfRange)
+}
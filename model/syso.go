@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/josephspurrier/goversioninfo"
+)
+
+// Archs are the architectures accepted by WriteSyso.
+var Archs = []string{"386", "amd64", "arm", "arm64"}
+
+// SysoFileName returns the platform specific name of the syso file for arch in the directory of fileName,
+// following goversioninfo's -platform-specific naming so that go build picks the right one.
+func SysoFileName(fileName string, arch string) string {
+	return filepath.Join(filepath.Dir(fileName), fmt.Sprintf("resource_windows_%s.syso", arch))
+}
+
+// ResolvePaths returns the info with relative IconPath and ManifestPath resolved against dir.
+func (i Info) ResolvePaths(dir string) (result Info) {
+	result = i
+	if result.IconPath != "" && !filepath.IsAbs(result.IconPath) {
+		result.IconPath = filepath.Join(dir, result.IconPath)
+	}
+	if result.ManifestPath != "" && !filepath.IsAbs(result.ManifestPath) {
+		result.ManifestPath = filepath.Join(dir, result.ManifestPath)
+	}
+	return result
+}
+
+func (i Info) WriteSyso(fileName string, arch string) error {
+	vi := goversioninfo.VersionInfo(i)
+	vi.Build()
+	vi.Walk()
+	return vi.WriteSyso(fileName, arch)
+}
+
+// SysoData returns the content of the syso file for arch, so that it can be written together with other files.
+func (i Info) SysoData(arch string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "exevup-syso-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "resource.syso")
+	if err = i.WriteSyso(fileName, arch); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(fileName)
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"debug/pe"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSysoFileName(t *testing.T) {
+	assert.Equal(t, "resource_windows_amd64.syso", SysoFileName("resource.syso", "amd64"))
+	assert.Equal(t, filepath.Join("cmd", "app", "resource_windows_arm64.syso"), SysoFileName(filepath.Join("cmd", "app", "resource.syso"), "arm64"))
+}
+
+func TestResolvePaths(t *testing.T) {
+	info := Info{IconPath: "icon.ico", ManifestPath: "/abs/app.manifest"}
+
+	result := info.ResolvePaths(filepath.Join("cmd", "app"))
+	assert.Equal(t, filepath.Join("cmd", "app", "icon.ico"), result.IconPath)
+	assert.Equal(t, "/abs/app.manifest", result.ManifestPath)
+	assert.Equal(t, "icon.ico", info.IconPath)
+
+	assert.Equal(t, "", Info{}.ResolvePaths("dir").IconPath)
+}
+
+func TestWriteSyso(t *testing.T) {
+	dir := t.TempDir()
+	machines := map[string]uint16{
+		"386":   pe.IMAGE_FILE_MACHINE_I386,
+		"amd64": pe.IMAGE_FILE_MACHINE_AMD64,
+		"arm":   pe.IMAGE_FILE_MACHINE_ARMNT,
+		"arm64": pe.IMAGE_FILE_MACHINE_ARM64,
+	}
+
+	for _, arch := range Archs {
+		t.Run(arch, func(t *testing.T) {
+			fileName := SysoFileName(filepath.Join(dir, "resource.syso"), arch)
+			require.NoError(t, DefaultInfo().WriteSyso(fileName, arch))
+
+			file, err := pe.Open(fileName)
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, machines[arch], file.Machine)
+			assert.NotNil(t, file.Section(".rsrc"))
+		})
+	}
+
+	t.Run("unknown arch", func(t *testing.T) {
+		assert.Error(t, DefaultInfo().WriteSyso(filepath.Join(dir, "unknown.syso"), "mips"))
+	})
+
+	t.Run("missing icon", func(t *testing.T) {
+		info := DefaultInfo()
+		info.IconPath = filepath.Join(dir, "missing.ico")
+		err := info.WriteSyso(filepath.Join(dir, "icon.syso"), "amd64")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestSysoData(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "resource.syso")
+	require.NoError(t, DefaultInfo().WriteSyso(fileName, "arm64"))
+	expected, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+
+	data, err := DefaultInfo().SysoData("arm64")
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	_, err = DefaultInfo().SysoData("mips")
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrInvalidTemplate = errors.New("invalid version template")
+)
+
+const templateNotationPrefix = "template:"
+
+// templateComponents are the names of the components in templates, in the order of Version.components.
+var templateComponents = []string{"Major", "Minor", "Patch", "Build"}
+
+// TemplateContext is what a template notation can reference besides the components of the version.
+type TemplateContext struct {
+	PreRelease string
+	Commit     string
+	Date       time.Time
+}
+
+// templateData is given to the template. Fields are any, so that the same template is executed
+// with the placeholders from which the pattern for VersionTemplate.Parse is built.
+type templateData struct {
+	Major      any
+	Minor      any
+	Patch      any
+	Build      any
+	PreRelease any
+	Commit     any
+	Date       any
+}
+
+// placeholderDate stands for Date while the pattern is built. Only Format is supported, so that the date can be skipped on parsing.
+type placeholderDate struct{}
+
+func (placeholderDate) Format(string) string {
+	return placeholderOf("Date")
+}
+
+func (placeholderDate) String() string {
+	return placeholderOf("Date")
+}
+
+func placeholderOf(name string) string {
+	return "\x00" + name + "\x00"
+}
+
+// VersionTemplate is a notation written as a text/template, e.g. {{.Major}}.{{.Minor}}.{{.Patch}} (build {{.Build}}).
+type VersionTemplate struct {
+	source   string
+	template *template.Template
+	patterns []*regexp.Regexp
+}
+
+// TemplateNotation returns the notation which writes versions by the template source.
+func TemplateNotation(source string) VersionNotation {
+	return VersionNotation(templateNotationPrefix + source)
+}
+
+func (n VersionNotation) IsTemplate() bool {
+	return strings.HasPrefix(string(n), templateNotationPrefix)
+}
+
+func (n VersionNotation) Template() (VersionTemplate, error) {
+	source, ok := strings.CutPrefix(string(n), templateNotationPrefix)
+	if !ok {
+		return VersionTemplate{}, fmt.Errorf("%w: notation %q is not a template", ErrInvalidTemplate, n)
+	}
+	return ParseVersionTemplate(source)
+}
+
+func ParseVersionTemplate(source string) (result VersionTemplate, err error) {
+	result.source = source
+	if result.template, err = template.New("version").Parse(source); err != nil {
+		return VersionTemplate{}, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	if _, err = result.Execute(Version{}, TemplateContext{}); err != nil {
+		return VersionTemplate{}, err
+	}
+
+	//PreRelease와 Commit은 비어 있을 때 {{with}} 등으로 생략될 수 있으므로 경우마다 패턴을 만듦
+	//패턴을 만들 수 없는 템플릿은 쓰기만 가능함
+	for _, optional := range [][2]bool{{true, true}, {true, false}, {false, true}, {false, false}} {
+		if pattern, err := result.patternOf(optional[0], optional[1]); err == nil {
+			result.patterns = append(result.patterns, pattern)
+		}
+	}
+	return result, nil
+}
+
+func (t VersionTemplate) String() string {
+	return t.source
+}
+
+func (t VersionTemplate) execute(data templateData) (string, error) {
+	var result bytes.Buffer
+	if err := t.template.Execute(&result, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return result.String(), nil
+}
+
+func (t VersionTemplate) Execute(v Version, context TemplateContext) (string, error) {
+	return t.execute(templateData{
+		Major:      v.Major,
+		Minor:      v.Minor,
+		Patch:      v.Patch,
+		Build:      v.Build,
+		PreRelease: context.PreRelease,
+		Commit:     context.Commit,
+		Date:       context.Date,
+	})
+}
+
+// patternOf executes the template with placeholders and turns its output into a regular expression
+// capturing each component, with or without the pre-release and commit.
+func (t VersionTemplate) patternOf(withPreRelease bool, withCommit bool) (*regexp.Regexp, error) {
+	data := templateData{
+		Major:      placeholderOf("Major"),
+		Minor:      placeholderOf("Minor"),
+		Patch:      placeholderOf("Patch"),
+		Build:      placeholderOf("Build"),
+		PreRelease: "",
+		Commit:     "",
+		Date:       placeholderDate{},
+	}
+	if withPreRelease {
+		data.PreRelease = placeholderOf("PreRelease")
+	}
+	if withCommit {
+		data.Commit = placeholderOf("Commit")
+	}
+
+	output, err := t.execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := regexp.QuoteMeta(output)
+	for _, name := range templateComponents {
+		//같은 구성 요소가 여러 번 쓰인 경우 첫 번째만 읽음
+		pattern = strings.Replace(pattern, placeholderOf(name), `(?P<`+name+`>\d+)`, 1)
+		pattern = strings.ReplaceAll(pattern, placeholderOf(name), `\d+`)
+	}
+	for _, name := range []string{"PreRelease", "Commit", "Date"} {
+		pattern = strings.ReplaceAll(pattern, placeholderOf(name), `.*?`)
+	}
+
+	return regexp.Compile("^" + pattern + "$")
+}
+
+// Parse extracts the components back out of versionString written by the template.
+// Components which the template does not write are 0. Templates which use Date other than by Format can not be parsed.
+func (t VersionTemplate) Parse(versionString string) (Version, error) {
+	result, _, err := t.parse(versionString)
+	return result, err
+}
+
+// parse is Parse also reporting which components the template writes, in the order of Version.components.
+func (t VersionTemplate) parse(versionString string) (result Version, written [4]bool, err error) {
+	for _, pattern := range t.patterns {
+		match := pattern.FindStringSubmatch(versionString)
+		if match == nil {
+			continue
+		}
+
+		for i, name := range pattern.SubexpNames() {
+			if name == "" {
+				continue
+			}
+
+			component, err := result.component(VersionLevel(strings.ToLower(name)))
+			if err != nil {
+				return Version{}, written, err
+			}
+			if *component, err = strconv.Atoi(match[i]); err != nil {
+				return Version{}, written, fmt.Errorf("%w: %q does not match %q", ErrInvalidTemplate, versionString, t.source)
+			}
+			written[slices.Index(templateComponents, name)] = true
+		}
+		return result, written, nil
+	}
+	return Version{}, written, fmt.Errorf("%w: %q does not match %q", ErrInvalidTemplate, versionString, t.source)
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionTemplate(t *testing.T) {
+	_, err := ParseVersionTemplate("{{.Major}")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	_, err = ParseVersionTemplate("{{.Major}}.{{.Unknown}}")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	notation := TemplateNotation("{{.Major}}.{{.Minor}}")
+	assert.True(t, notation.IsTemplate())
+	assert.False(t, NotationDetail.IsTemplate())
+
+	result, err := notation.Template()
+	require.NoError(t, err)
+	assert.Equal(t, "{{.Major}}.{{.Minor}}", result.String())
+
+	_, err = NotationNormal.Template()
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
+
+func TestVersionTemplate(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	version := Version{Major: 1, Minor: 2, Patch: 3, Build: 456}
+
+	tests := []struct {
+		name     string
+		source   string
+		context  TemplateContext
+		expected string
+		parsed   Version
+	}{
+		{
+			name:     "build in parentheses",
+			source:   "{{.Major}}.{{.Minor}}.{{.Patch}} (build {{.Build}})",
+			expected: "1.2.3 (build 456)",
+			parsed:   version,
+		},
+		{
+			name:     "pre-release",
+			source:   "v{{.Major}}.{{.Minor}}{{with .PreRelease}}-{{.}}{{end}}",
+			context:  TemplateContext{PreRelease: "beta"},
+			expected: "v1.2-beta",
+			parsed:   Version{Major: 1, Minor: 2},
+		},
+		{
+			name:     "without pre-release",
+			source:   "v{{.Major}}.{{.Minor}}{{with .PreRelease}}-{{.}}{{end}}",
+			expected: "v1.2",
+			parsed:   Version{Major: 1, Minor: 2},
+		},
+		{
+			name:     "commit and date",
+			source:   "{{.Major}}.{{.Minor}}.{{.Patch}}.{{.Build}}+{{.Commit}} {{.Date.Format \"2006-01-02\"}}",
+			context:  TemplateContext{Commit: "abc1234", Date: date},
+			expected: "1.2.3.456+abc1234 2024-03-05",
+			parsed:   version,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := ParseVersionTemplate(tt.source)
+			require.NoError(t, err)
+
+			result, err := template.Execute(version, tt.context)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			parsed, err := template.Parse(result)
+			require.NoError(t, err)
+			assert.Equal(t, tt.parsed, parsed)
+		})
+	}
+
+	template, err := ParseVersionTemplate("{{.Major}}.{{.Minor}} (build {{.Build}})")
+	require.NoError(t, err)
+	_, err = template.Parse("1.2.3")
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
+
+func TestInfoLevelsUpdatedWithTemplate(t *testing.T) {
+	info := Info{}
+	info.StringFileInfo.ProductVersion = "1.2.3 (build 456)"
+	notation := TemplateNotation("{{.Major}}.{{.Minor}}.{{.Patch}} (build {{.Build}})")
+
+	result, err := info.LevelsUpdated(TargetUpdate{}, TargetUpdate{Level: LevelBuild, Notation: notation})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3 (build 457)", result.StringFileInfo.ProductVersion)
+	assert.Equal(t, goversioninfo.FileVersion{Major: 1, Minor: 2, Patch: 3, Build: 457}, result.FixedFileInfo.ProductVersion)
+
+	_, err = info.LevelsUpdated(TargetUpdate{}, TargetUpdate{Level: LevelBuild, Notation: TemplateNotation("{{.Major")})
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+}
@@ -0,0 +1,426 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// errNotPatchable is returned when a value is inside an inline table or an array, so that the document is encoded instead.
+var errNotPatchable = errors.New("value can not be patched")
+
+type tomlCodec struct{}
+
+func (tomlCodec) Decode(data []byte) (Info, error) {
+	var value map[string]any
+	if _, err := toml.Decode(string(data), &value); err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return Info{}, ParseError{Line: parseErr.Position.Line, Column: parseErr.Position.Col, Err: errors.New(parseErr.Message)}
+		}
+		return Info{}, ParseError{Err: err}
+	}
+	return infoOf(value, nil)
+}
+
+func tomlKeyOf(path ...string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		if bareKeyPattern.MatchString(key) {
+			keys[i] = key
+		} else {
+			quoted, _ := json.Marshal(key)
+			keys[i] = string(quoted)
+		}
+	}
+	return strings.Join(keys, ".")
+}
+
+// Encode writes the values of each table in field order. JSON encoding of strings, numbers and booleans is valid in TOML.
+func (tomlCodec) Encode(info Info) ([]byte, error) {
+	values, err := leaves(info)
+	if err != nil {
+		return nil, err
+	}
+
+	//상위 테이블이 하위 테이블보다 먼저 오도록 경로의 각 접두사가 처음 나온 순서로 테이블을 씀
+	tables := [][]string{nil}
+	tableValues := make(map[string][]leaf)
+	for _, value := range values {
+		for depth := 1; depth < len(value.path); depth++ {
+			if table := value.path[:depth]; !slices.ContainsFunc(tables, func(path []string) bool { return slices.Equal(path, table) }) {
+				tables = append(tables, table)
+			}
+		}
+		table := joinPath(value.path[:len(value.path)-1])
+		tableValues[table] = append(tableValues[table], value)
+	}
+
+	var result bytes.Buffer
+	for _, table := range tables {
+		values := tableValues[joinPath(table)]
+		if len(values) == 0 {
+			continue
+		}
+
+		if len(table) > 0 {
+			if result.Len() > 0 {
+				result.WriteString("\n")
+			}
+			fmt.Fprintf(&result, "[%s]\n", tomlKeyOf(table...))
+		}
+		for _, value := range values {
+			fmt.Fprintf(&result, "%s = %s\n", tomlKeyOf(value.path[len(value.path)-1]), value.raw)
+		}
+	}
+	return result.Bytes(), nil
+}
+
+// tomlTable is a table header in a TOML document.
+type tomlTable struct {
+	path  []string
+	start int
+	end   int
+	array bool
+}
+
+// tomlValue is a key/value pair in a TOML document. Path is the full path including the table,
+// and lineEnd is where the line of the pair ends, after its newline.
+type tomlValue struct {
+	path    []string
+	table   int
+	start   int
+	end     int
+	lineEnd int
+}
+
+type tomlDocument struct {
+	data   []byte
+	tables []tomlTable
+	values []tomlValue
+}
+
+type tomlScanner struct {
+	data   []byte
+	offset int
+}
+
+// scanTOML finds the tables and values of data, which must be valid TOML.
+func scanTOML(data []byte) (result tomlDocument, err error) {
+	result.data = data
+	s := &tomlScanner{data: data}
+	table := -1
+	for {
+		s.skipSpace()
+		if s.offset >= len(data) {
+			return result, nil
+		}
+
+		switch data[s.offset] {
+		case '\n', '#':
+			s.skipLine()
+		case '[':
+			header := tomlTable{start: s.offset}
+			s.offset++
+			if header.array = s.peek('['); header.array {
+				s.offset++
+			}
+			if header.path, err = s.key(); err != nil {
+				return tomlDocument{}, err
+			}
+			s.skipLine()
+			header.end = s.offset
+			result.tables = append(result.tables, header)
+			table = len(result.tables) - 1
+		default:
+			value := tomlValue{table: table}
+			if table >= 0 {
+				value.path = slices.Clone(result.tables[table].path)
+			}
+
+			key, err := s.key()
+			if err != nil {
+				return tomlDocument{}, err
+			}
+			value.path = append(value.path, key...)
+
+			s.skipSpace()
+			if !s.peek('=') {
+				return tomlDocument{}, fmt.Errorf("expected = after %s at offset %d", tomlKeyOf(key...), s.offset)
+			}
+			s.offset++
+			s.skipSpace()
+
+			value.start = s.offset
+			if err = s.value(); err != nil {
+				return tomlDocument{}, err
+			}
+			value.end = s.offset
+			s.skipLine()
+			value.lineEnd = s.offset
+			result.values = append(result.values, value)
+		}
+	}
+}
+
+func (s *tomlScanner) peek(c byte) bool {
+	return s.offset < len(s.data) && s.data[s.offset] == c
+}
+
+func (s *tomlScanner) skipSpace() {
+	for s.offset < len(s.data) && (s.data[s.offset] == ' ' || s.data[s.offset] == '\t' || s.data[s.offset] == '\r') {
+		s.offset++
+	}
+}
+
+// skipLine skips the rest of the line including a comment and the newline.
+func (s *tomlScanner) skipLine() {
+	if index := bytes.IndexByte(s.data[s.offset:], '\n'); index >= 0 {
+		s.offset += index + 1
+	} else {
+		s.offset = len(s.data)
+	}
+}
+
+// key reads a dotted key, which ends before = or ].
+func (s *tomlScanner) key() (result []string, err error) {
+	for {
+		s.skipSpace()
+		if s.offset >= len(s.data) {
+			return nil, fmt.Errorf("unexpected end of key at offset %d", s.offset)
+		}
+
+		var part string
+		switch s.data[s.offset] {
+		case '"':
+			start := s.offset
+			if err = s.basicString(); err != nil {
+				return nil, err
+			}
+			if err = json.Unmarshal(s.data[start:s.offset], &part); err != nil {
+				return nil, fmt.Errorf("invalid key at offset %d: %w", start, err)
+			}
+		case '\'':
+			start := s.offset
+			if err = s.literalString(); err != nil {
+				return nil, err
+			}
+			part = string(s.data[start+1 : s.offset-1])
+		default:
+			start := s.offset
+			for s.offset < len(s.data) && strings.IndexByte("=]. \t\r\n", s.data[s.offset]) < 0 {
+				s.offset++
+			}
+			part = string(s.data[start:s.offset])
+		}
+		result = append(result, part)
+
+		s.skipSpace()
+		if !s.peek('.') {
+			if s.peek(']') {
+				s.offset++
+			}
+			return result, nil
+		}
+		s.offset++
+	}
+}
+
+// closing finds the end of a string starting at the offset, which ends with delimiter.
+func (s *tomlScanner) closing(delimiter string, escape bool) error {
+	start := s.offset
+	s.offset += len(delimiter)
+	for s.offset < len(s.data) {
+		switch {
+		case escape && s.data[s.offset] == '\\':
+			s.offset += 2
+		case bytes.HasPrefix(s.data[s.offset:], []byte(delimiter)):
+			s.offset += len(delimiter)
+			//여러 줄 문자열은 구분자 뒤에 따옴표가 두 개까지 더 올 수 있음
+			for extra := 0; len(delimiter) == 3 && extra < 2 && s.peek(delimiter[0]); extra++ {
+				s.offset++
+			}
+			return nil
+		default:
+			s.offset++
+		}
+	}
+	return fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func (s *tomlScanner) basicString() error {
+	if bytes.HasPrefix(s.data[s.offset:], []byte(`"""`)) {
+		return s.closing(`"""`, true)
+	}
+	return s.closing(`"`, true)
+}
+
+func (s *tomlScanner) literalString() error {
+	if bytes.HasPrefix(s.data[s.offset:], []byte(`'''`)) {
+		return s.closing(`'''`, false)
+	}
+	return s.closing(`'`, false)
+}
+
+// value skips a value, which may span lines when it is a multi-line string, an array or an inline table.
+func (s *tomlScanner) value() (err error) {
+	depth := 0
+	for s.offset < len(s.data) {
+		c := s.data[s.offset]
+		switch {
+		case c == '"':
+			err = s.basicString()
+		case c == '\'':
+			err = s.literalString()
+		case c == '[' || c == '{':
+			depth++
+			s.offset++
+		case c == ']' || c == '}':
+			depth--
+			s.offset++
+		case depth > 0 && c == '#':
+			s.skipLine()
+		case depth == 0 && strings.IndexByte("# \t\r\n", c) >= 0 && !s.isDateTimeSpace():
+			return nil
+		default:
+			s.offset++
+		}
+		if err != nil || (depth == 0 && strings.IndexByte(`"']}`, c) >= 0) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDateTimeSpace reports whether the space at the offset separates the date and time of a datetime, e.g. 1979-05-27 07:32:00.
+func (s *tomlScanner) isDateTimeSpace() bool {
+	if s.data[s.offset] != ' ' || s.offset < 10 || s.offset+3 > len(s.data) {
+		return false
+	}
+	date, time := s.data[s.offset-10:s.offset], s.data[s.offset+1:s.offset+3]
+	return date[4] == '-' && date[7] == '-' && isDigit(time[0]) && isDigit(time[1])
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (d tomlDocument) replaced(start int, end int, text string) []byte {
+	result := make([]byte, 0, len(d.data)-(end-start)+len(text))
+	result = append(result, d.data[:start]...)
+	result = append(result, text...)
+	return append(result, d.data[end:]...)
+}
+
+// lineIndent returns the indentation of the line containing offset.
+func (d tomlDocument) lineIndent(offset int) string {
+	start := bytes.LastIndexByte(d.data[:offset], '\n') + 1
+	end := start
+	for end < len(d.data) && (d.data[end] == ' ' || d.data[end] == '\t') {
+		end++
+	}
+	return string(d.data[start:end])
+}
+
+func (d tomlDocument) tablePath(table int) []string {
+	if table < 0 {
+		return nil
+	}
+	return d.tables[table].path
+}
+
+func (d tomlDocument) isArrayTable(table int) bool {
+	return table >= 0 && d.tables[table].array
+}
+
+// set replaces the value at path with raw, or inserts it after the last value of its table.
+// A table which has no place to insert is appended at the end.
+func (d tomlDocument) set(path []string, raw json.RawMessage) ([]byte, error) {
+	table := path[:len(path)-1]
+
+	last := -1
+	for i, value := range d.values {
+		switch {
+		case d.isArrayTable(value.table):
+			continue
+		case slices.Equal(value.path, path):
+			return d.replaced(value.start, value.end, string(raw)), nil
+		case len(value.path) < len(path) && slices.Equal(value.path, path[:len(value.path)]):
+			return nil, fmt.Errorf("%w: %s is in an inline table or array", errNotPatchable, tomlKeyOf(path...))
+		}
+
+		header := d.tablePath(value.table)
+		if len(value.path) > len(table) && slices.Equal(value.path[:len(table)], table) && len(header) <= len(table) && slices.Equal(header, table[:len(header)]) {
+			last = i
+		}
+	}
+
+	if last >= 0 {
+		value := d.values[last]
+		line := fmt.Sprintf("%s%s = %s\n", d.lineIndent(value.start), tomlKeyOf(path[len(d.tablePath(value.table)):]...), raw)
+		return d.insertedLine(value.lineEnd, line), nil
+	}
+
+	line := fmt.Sprintf("%s = %s\n", tomlKeyOf(path[len(path)-1]), raw)
+	for _, header := range d.tables {
+		if !header.array && slices.Equal(header.path, table) {
+			return d.insertedLine(header.end, line), nil
+		}
+	}
+
+	if len(table) == 0 {
+		if len(d.tables) > 0 {
+			return d.replaced(d.tables[0].start, d.tables[0].start, line+"\n"), nil
+		}
+		return d.insertedLine(len(d.data), line), nil
+	}
+	return d.insertedLine(len(d.data), fmt.Sprintf("\n[%s]\n%s", tomlKeyOf(table...), line)), nil
+}
+
+// insertedLine inserts line at offset, adding a newline before it when the document does not end with one.
+func (d tomlDocument) insertedLine(offset int, line string) []byte {
+	if offset > 0 && d.data[offset-1] != '\n' {
+		line = "\n" + line
+	}
+	return d.replaced(offset, offset, line)
+}
+
+// Patch keeps comments and formatting of original. When a changed value is inside an inline table or an array,
+// the whole info is encoded instead.
+func (c tomlCodec) Patch(original []byte, info Info) ([]byte, error) {
+	if len(bytes.TrimSpace(original)) == 0 {
+		return c.Encode(info)
+	}
+
+	current, err := c.Decode(original)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := changedLeaves(current, info)
+	if err != nil {
+		return nil, err
+	}
+
+	result := original
+	for _, value := range changed {
+		document, err := scanTOML(result)
+		if err != nil {
+			return nil, err
+		}
+		if result, err = document.set(value.path, value.raw); errors.Is(err, errNotPatchable) {
+			return c.Encode(info)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
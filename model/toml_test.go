@@ -0,0 +1,125 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanTOML(t *testing.T) {
+	document, err := scanTOML([]byte(`title = """
+multi "" line""" # comment
+[a . "b.c"]
+list = [
+  1, # one
+  "]",
+]
+date = 1979-05-27 07:32:00 # local
+[[items]]
+x = 'y'
+`))
+	require.NoError(t, err)
+
+	var paths [][]string
+	var values []string
+	for _, value := range document.values {
+		paths = append(paths, value.path)
+		values = append(values, string(document.data[value.start:value.end]))
+	}
+	assert.Equal(t, [][]string{{"title"}, {"a", "b.c", "list"}, {"a", "b.c", "date"}, {"items", "x"}}, paths)
+	assert.Equal(t, []string{"\"\"\"\nmulti \"\" line\"\"\"", "[\n  1, # one\n  \"]\",\n]", "1979-05-27 07:32:00", "'y'"}, values)
+	require.Len(t, document.tables, 2)
+	assert.True(t, document.tables[1].array)
+}
+
+func TestTOMLDecode(t *testing.T) {
+	info, err := tomlCodec{}.Decode([]byte(`[StringFileInfo]
+FileVersion = "1.2"
+[FixedFileInfo]
+FileVersion.Major = 1
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2", info.StringFileInfo.FileVersion)
+	assert.Equal(t, 1, info.FixedFileInfo.FileVersion.Major)
+
+	_, err = tomlCodec{}.Decode([]byte("[StringFileInfo]\nFileVersion = 1.2.3\n"))
+	var parseErr ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 2, parseErr.Line)
+
+	_, err = tomlCodec{}.Decode([]byte("[StringFileInfo]\nFileVersion = 1.2\n"))
+	assert.ErrorAs(t, err, &parseErr)
+}
+
+func TestTOMLPatch(t *testing.T) {
+	original := []byte(`# version of the tool
+IconPath = "icon.ico"
+
+[StringFileInfo]
+FileVersion = "1.2.3" # released
+  CompanyName = 'Example Corp'
+
+[FixedFileInfo]
+FileVersion = { Major = 1, Minor = 2, Patch = 3 }
+`)
+	info, err := tomlCodec{}.Decode(original)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		update   func(info Info) Info
+		expected string
+	}{
+		{
+			name: "replace",
+			update: func(info Info) Info {
+				info.StringFileInfo.FileVersion = "1.3.0"
+				info.StringFileInfo.CompanyName = "Example Corp"
+				return info
+			},
+			expected: strings.Replace(string(original), `"1.2.3"`, `"1.3.0"`, 1),
+		},
+		{
+			name: "insert",
+			update: func(info Info) Info {
+				info.StringFileInfo.ProductName = "exevup"
+				info.ManifestPath = "app.manifest"
+				info.VarFileInfo.Translation.LangID = 0x0409
+				return info
+			},
+			expected: `# version of the tool
+IconPath = "icon.ico"
+ManifestPath = "app.manifest"
+
+[StringFileInfo]
+FileVersion = "1.2.3" # released
+  CompanyName = 'Example Corp'
+  ProductName = "exevup"
+
+[FixedFileInfo]
+FileVersion = { Major = 1, Minor = 2, Patch = 3 }
+
+[VarFileInfo.Translation]
+LangID = 1033
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := tomlCodec{}.Patch(original, tt.update(info))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(patched))
+		})
+	}
+
+	t.Run("inline table", func(t *testing.T) {
+		updated := info.VersionUpdated(Version{Major: 2}, Version{}, TargetFile, NotationNormal)
+		patched, err := tomlCodec{}.Patch(original, updated)
+		require.NoError(t, err)
+		encoded, err := tomlCodec{}.Encode(updated)
+		require.NoError(t, err)
+		assert.Equal(t, string(encoded), string(patched))
+	})
+}
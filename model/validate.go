@@ -0,0 +1,275 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/josephspurrier/goversioninfo"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// IssueCode identifies the kind of an Issue. Codes are stable, so they can be matched by CI scripts.
+type IssueCode string
+
+const (
+	IssueMissingVersion       IssueCode = "missing-version"
+	IssueInvalidVersion       IssueCode = "invalid-version"
+	IssueVersionMismatch      IssueCode = "version-mismatch"
+	IssueVersionOutOfRange    IssueCode = "version-out-of-range"
+	IssueMissingRequiredField IssueCode = "missing-required-field"
+	IssueInvalidHex           IssueCode = "invalid-hex"
+	IssueUnknownLanguage      IssueCode = "unknown-language"
+	IssueUnknownCharset       IssueCode = "unknown-charset"
+	IssueMissingFileExtension IssueCode = "missing-file-extension"
+	IssueMissingIcon          IssueCode = "missing-icon"
+	IssueMissingManifest      IssueCode = "missing-manifest"
+)
+
+const (
+	maxVersionComponent       = 0xFFFF
+	stringFileInfoFieldPrefix = "StringFileInfo."
+)
+
+type Issue struct {
+	Code     IssueCode `json:"code"`
+	Severity Severity  `json:"severity"`
+	Field    string    `json:"field"`
+	Message  string    `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %s %s: %s", i.Severity, i.Code, i.Field, i.Message)
+}
+
+// RequiredStringFields are the StringFileInfo fields which Windows documents as required, besides the versions.
+var RequiredStringFields = []StringField{
+	FieldCompanyName,
+	FieldFileDescription,
+	FieldInternalName,
+	FieldOriginalFilename,
+	FieldProductName,
+}
+
+// languages are the language identifiers documented for the VERSIONINFO resource.
+var languages = map[goversioninfo.LangID]bool{
+	0x0401: true, 0x0402: true, 0x0403: true, 0x0404: true, 0x0405: true, 0x0406: true, 0x0407: true, 0x0408: true,
+	0x0409: true, 0x040A: true, 0x040B: true, 0x040C: true, 0x040D: true, 0x040E: true, 0x040F: true, 0x0410: true,
+	0x0411: true, 0x0412: true, 0x0413: true, 0x0414: true, 0x0415: true, 0x0416: true, 0x0417: true, 0x0418: true,
+	0x0419: true, 0x041A: true, 0x041B: true, 0x041C: true, 0x041D: true, 0x041E: true, 0x041F: true, 0x0420: true,
+	0x0421: true, 0x0804: true, 0x0807: true, 0x0809: true, 0x080A: true, 0x080C: true, 0x0810: true, 0x0813: true,
+	0x0814: true, 0x0816: true, 0x081A: true, 0x0C0C: true, 0x100C: true,
+}
+
+// charsets are the character set identifiers documented for the VERSIONINFO resource.
+var charsets = map[goversioninfo.CharsetID]bool{
+	goversioninfo.Cs7ASCII:       true,
+	goversioninfo.CsJIS:          true,
+	goversioninfo.CsKSC:          true,
+	goversioninfo.CsBig5:         true,
+	goversioninfo.CsUnicode:      true,
+	goversioninfo.CsLatin2:       true,
+	goversioninfo.CsCyrillic:     true,
+	goversioninfo.CsMultilingual: true,
+	goversioninfo.CsGreek:        true,
+	goversioninfo.CsTurkish:      true,
+	goversioninfo.CsHebrew:       true,
+	goversioninfo.CsArabic:       true,
+}
+
+// Validate returns the problems found in info. Relative IconPath and ManifestPath are checked against
+// the working directory, so use ResolvePaths beforehand to check them against another directory.
+func Validate(info Info) []Issue {
+	return ValidateAs(info, "")
+}
+
+// ValidateAs is Validate reading the version strings by notation, which is needed for template notations.
+// A blank notation or any other notation reads them as plain versions or SemVer.
+func ValidateAs(info Info, notation VersionNotation) (issues []Issue) {
+	issues = append(issues, validateVersion("FileVersion", Version(info.FixedFileInfo.FileVersion), info.StringFileInfo.FileVersion, notation)...)
+	issues = append(issues, validateVersion("ProductVersion", Version(info.FixedFileInfo.ProductVersion), info.StringFileInfo.ProductVersion, notation)...)
+
+	for _, field := range RequiredStringFields {
+		if strings.TrimSpace(info.StringField(field)) == "" {
+			issues = append(issues, Issue{
+				Code:     IssueMissingRequiredField,
+				Severity: SeverityError,
+				Field:    stringFileInfoFieldPrefix + string(field),
+				Message:  "required field is empty",
+			})
+		}
+	}
+
+	fixed := info.FixedFileInfo
+	for _, flag := range []struct {
+		name  string
+		value string
+	}{
+		{name: "FileFlagsMask", value: fixed.FileFlagsMask},
+		{name: "FileFlags", value: fixed.FileFlags},
+		{name: "FileOS", value: fixed.FileOS},
+		{name: "FileType", value: fixed.FileType},
+		{name: "FileSubType", value: fixed.FileSubType},
+	} {
+		if _, err := strconv.ParseUint(flag.value, 16, 32); flag.value != "" && err != nil {
+			issues = append(issues, Issue{
+				Code:     IssueInvalidHex,
+				Severity: SeverityError,
+				Field:    "FixedFileInfo." + flag.name,
+				Message:  fmt.Sprintf("%q is not a 32-bit hexadecimal number, goversioninfo writes 0 instead", flag.value),
+			})
+		}
+	}
+
+	translation := info.VarFileInfo.Translation
+	if !languages[translation.LangID] {
+		issues = append(issues, Issue{
+			Code:     IssueUnknownLanguage,
+			Severity: SeverityWarning,
+			Field:    "VarFileInfo.Translation.LangID",
+			Message:  fmt.Sprintf("%04X is not a language documented for version resources", uint16(translation.LangID)),
+		})
+	}
+	if !charsets[translation.CharsetID] {
+		issues = append(issues, Issue{
+			Code:     IssueUnknownCharset,
+			Severity: SeverityError,
+			Field:    "VarFileInfo.Translation.CharsetID",
+			Message:  fmt.Sprintf("%04X is not a character set documented for version resources", uint16(translation.CharsetID)),
+		})
+	}
+
+	if name := info.StringFileInfo.OriginalFilename; name != "" && filepath.Ext(name) == "" {
+		issues = append(issues, Issue{
+			Code:     IssueMissingFileExtension,
+			Severity: SeverityWarning,
+			Field:    stringFileInfoFieldPrefix + string(FieldOriginalFilename),
+			Message:  fmt.Sprintf("%q has no extension such as .exe or .dll", name),
+		})
+	}
+
+	if issue, ok := validatePath("IconPath", info.IconPath, IssueMissingIcon); ok {
+		issues = append(issues, issue)
+	}
+	if issue, ok := validatePath("ManifestPath", info.ManifestPath, IssueMissingManifest); ok {
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+func validateVersion(name string, fixed Version, versionString string, notation VersionNotation) (issues []Issue) {
+	for i, component := range fixed.components() {
+		if component < 0 || component > maxVersionComponent {
+			issues = append(issues, Issue{
+				Code:     IssueVersionOutOfRange,
+				Severity: SeverityError,
+				Field:    "FixedFileInfo." + name,
+				Message:  fmt.Sprintf("component %d is %d, which does not fit in 16 bits", i+1, component),
+			})
+		}
+	}
+
+	if versionString == "" {
+		if fixed.isEmpty() {
+			issues = append(issues, Issue{
+				Code:     IssueMissingVersion,
+				Severity: SeverityError,
+				Field:    name,
+				Message:  "neither FixedFileInfo nor StringFileInfo has a version",
+			})
+		}
+		return issues
+	}
+
+	version, written, err := versionComponentsOf(versionString, notation)
+	if err != nil {
+		severity := SeverityWarning
+		if fixed.isEmpty() {
+			severity = SeverityError
+		}
+		return append(issues, Issue{
+			Code:     IssueInvalidVersion,
+			Severity: severity,
+			Field:    stringFileInfoFieldPrefix + name,
+			Message:  fmt.Sprintf("%q can not be read as a version", versionString),
+		})
+	}
+
+	//goversioninfo는 FixedFileInfo만으로 바이너리 버전을 만들기 때문에 비어 있어도 비교함
+	components, fixedComponents := version.components(), fixed.components()
+	mismatch := false
+	for i := range components {
+		mismatch = mismatch || (written[i] && components[i] != fixedComponents[i])
+	}
+	if mismatch {
+		issues = append(issues, Issue{
+			Code:     IssueVersionMismatch,
+			Severity: SeverityError,
+			Field:    name,
+			Message:  fmt.Sprintf("StringFileInfo has %q but FixedFileInfo has %s", versionString, fixed.String(NotationDetail)),
+		})
+	}
+	return issues
+}
+
+// versionComponentsOf returns the version written in versionString and which of its components are written,
+// so that a string in normal notation is compared only with major, minor and patch. For SemVer, the pre-release is not compared.
+// With a template notation, versionString is read by the template.
+func versionComponentsOf(versionString string, notation VersionNotation) (version Version, written [4]bool, err error) {
+	if notation.IsTemplate() {
+		t, err := notation.Template()
+		if err != nil {
+			return Version{}, written, err
+		}
+		return t.parse(versionString)
+	}
+
+	if version, err = ParseVersion(versionString); err == nil {
+		for i := 0; i <= strings.Count(versionString, "."); i++ {
+			written[i] = true
+		}
+		return version, written, nil
+	}
+
+	semVer, err := ParseSemVer(versionString)
+	if err != nil {
+		return Version{}, written, err
+	}
+	return Version{Major: semVer.Major, Minor: semVer.Minor, Patch: semVer.Patch}, [4]bool{true, true, true, false}, nil
+}
+
+func validatePath(field string, path string, code IssueCode) (Issue, bool) {
+	if path == "" {
+		return Issue{}, false
+	}
+
+	_, err := os.Stat(path)
+	if err == nil {
+		return Issue{}, false
+	}
+
+	message := err.Error()
+	if errors.Is(err, os.ErrNotExist) {
+		message = fmt.Sprintf("%q does not exist", path)
+	}
+	return Issue{Code: code, Severity: SeverityError, Field: field, Message: message}, true
+}
+
+func HasError(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
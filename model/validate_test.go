@@ -0,0 +1,185 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validInfo() Info {
+	info := DefaultInfo()
+	info.StringFileInfo.CompanyName = "Example Corp"
+	info.StringFileInfo.FileDescription = "Example tool"
+	info.StringFileInfo.InternalName = "example"
+	info.StringFileInfo.OriginalFilename = "example.exe"
+	info.StringFileInfo.ProductName = "Example"
+	return info
+}
+
+func codesOf(issues []Issue) (codes []IssueCode) {
+	for _, issue := range issues {
+		codes = append(codes, issue.Code)
+	}
+	return
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(info *Info)
+		expected []IssueCode
+		hasError bool
+	}{
+		{
+			name:   "valid",
+			modify: func(info *Info) {},
+		},
+		{
+			name: "version mismatch",
+			modify: func(info *Info) {
+				info.StringFileInfo.FileVersion = "1.0.1"
+			},
+			expected: []IssueCode{IssueVersionMismatch},
+			hasError: true,
+		},
+		{
+			name: "shorter notation matches",
+			modify: func(info *Info) {
+				info.FixedFileInfo.ProductVersion.Build = 7
+				info.StringFileInfo.ProductVersion = "1.0.0"
+			},
+		},
+		{
+			name: "semver matches without pre-release",
+			modify: func(info *Info) {
+				*info = info.SemVerUpdated(SemVer{Major: 1, Minor: 4, PreRelease: []string{"rc", "2"}}, SemVer{Major: 1, Minor: 4}, TargetBoth)
+			},
+		},
+		{
+			name: "empty FixedFileInfo",
+			modify: func(info *Info) {
+				info.FixedFileInfo.FileVersion = goversioninfo.FileVersion{}
+			},
+			expected: []IssueCode{IssueVersionMismatch},
+			hasError: true,
+		},
+		{
+			name: "missing version",
+			modify: func(info *Info) {
+				info.FixedFileInfo.FileVersion = goversioninfo.FileVersion{}
+				info.StringFileInfo.FileVersion = ""
+			},
+			expected: []IssueCode{IssueMissingVersion},
+			hasError: true,
+		},
+		{
+			name: "free form version string",
+			modify: func(info *Info) {
+				info.StringFileInfo.ProductVersion = "1.0 beta"
+			},
+			expected: []IssueCode{IssueInvalidVersion},
+		},
+		{
+			name: "component out of range",
+			modify: func(info *Info) {
+				*info = info.VersionUpdated(Version{Major: 1, Build: 70000}, Version{Major: 1}, TargetFile, NotationDetail)
+			},
+			expected: []IssueCode{IssueVersionOutOfRange},
+			hasError: true,
+		},
+		{
+			name: "missing required fields",
+			modify: func(info *Info) {
+				info.StringFileInfo.CompanyName = ""
+				info.StringFileInfo.ProductName = " "
+			},
+			expected: []IssueCode{IssueMissingRequiredField, IssueMissingRequiredField},
+			hasError: true,
+		},
+		{
+			name: "invalid hex",
+			modify: func(info *Info) {
+				info.FixedFileInfo.FileOS = "windows"
+			},
+			expected: []IssueCode{IssueInvalidHex},
+			hasError: true,
+		},
+		{
+			name: "unknown language",
+			modify: func(info *Info) {
+				info.VarFileInfo.Translation.LangID = 0x0C0A
+			},
+			expected: []IssueCode{IssueUnknownLanguage},
+		},
+		{
+			name: "unknown charset",
+			modify: func(info *Info) {
+				info.VarFileInfo.Translation.CharsetID = 65001
+			},
+			expected: []IssueCode{IssueUnknownCharset},
+			hasError: true,
+		},
+		{
+			name: "original filename without extension",
+			modify: func(info *Info) {
+				info.StringFileInfo.OriginalFilename = "example"
+			},
+			expected: []IssueCode{IssueMissingFileExtension},
+		},
+		{
+			name: "missing icon and manifest",
+			modify: func(info *Info) {
+				info.IconPath = filepath.Join(t.TempDir(), "missing.ico")
+				info.ManifestPath = filepath.Join(t.TempDir(), "missing.manifest")
+			},
+			expected: []IssueCode{IssueMissingIcon, IssueMissingManifest},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := validInfo()
+			tt.modify(&info)
+
+			issues := Validate(info)
+			assert.Equal(t, tt.expected, codesOf(issues))
+			assert.Equal(t, tt.hasError, HasError(issues))
+		})
+	}
+}
+
+func TestValidateAs(t *testing.T) {
+	notation := TemplateNotation("{{.Major}}.{{.Minor}} (build {{.Build}})")
+	info := validInfo()
+	info.FixedFileInfo.FileVersion.Build = 7
+	info.StringFileInfo.FileVersion = "1.0 (build 7)"
+	info.StringFileInfo.ProductVersion = "1.0 (build 0)"
+
+	assert.Empty(t, ValidateAs(info, notation))
+	assert.Equal(t, []IssueCode{IssueInvalidVersion, IssueInvalidVersion}, codesOf(Validate(info)))
+
+	info.StringFileInfo.FileVersion = "1.0 (build 8)"
+	assert.Equal(t, []IssueCode{IssueVersionMismatch}, codesOf(ValidateAs(info, notation)))
+
+	info.StringFileInfo.FileVersion = "1.0 beta"
+	assert.Equal(t, []IssueCode{IssueInvalidVersion}, codesOf(ValidateAs(info, notation)))
+}
+
+func TestValidateExistingIcon(t *testing.T) {
+	info := validInfo()
+	info.IconPath = filepath.Join(t.TempDir(), "icon.ico")
+	require.NoError(t, os.WriteFile(info.IconPath, []byte{}, 0644))
+
+	assert.Empty(t, Validate(info))
+}
+
+func TestIssueString(t *testing.T) {
+	issue := Issue{Code: IssueUnknownCharset, Severity: SeverityError, Field: "VarFileInfo.Translation.CharsetID", Message: "FDE9 is not a character set"}
+
+	assert.Equal(t, "error unknown-charset VarFileInfo.Translation.CharsetID: FDE9 is not a character set", issue.String())
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -22,6 +23,9 @@ const (
 	LevelMinor VersionLevel = "minor"
 	LevelPatch VersionLevel = "patch"
 	LevelBuild VersionLevel = "build"
+
+	LevelPreRelease VersionLevel = "prerelease"
+	LevelRelease    VersionLevel = "release"
 )
 
 type VersionNotation string
@@ -30,6 +34,7 @@ const (
 	NotationSimple VersionNotation = "simple"
 	NotationNormal VersionNotation = "normal"
 	NotationDetail VersionNotation = "detail"
+	NotationSemVer VersionNotation = "semver"
 )
 
 type VersionTarget string
@@ -40,7 +45,7 @@ const (
 	TargetProduct VersionTarget = "product"
 )
 
-func parseVersion(versionString string) (result Version, err error) {
+func ParseVersion(versionString string) (result Version, err error) {
 	separated := strings.Split(versionString, ".")
 	var element int
 	if versionString == "" {
@@ -86,6 +91,20 @@ func (v Version) isEmpty() bool {
 	return v.Major == 0 && v.Minor == 0 && v.Patch == 0 && v.Build == 0
 }
 
+func (v Version) components() [4]int {
+	return [4]int{v.Major, v.Minor, v.Patch, v.Build}
+}
+
+func versionOf(components [4]int) Version {
+	return Version{Major: components[0], Minor: components[1], Patch: components[2], Build: components[3]}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to or higher than other.
+func (v Version) Compare(other Version) int {
+	components, otherComponents := v.components(), other.components()
+	return slices.Compare(components[:], otherComponents[:])
+}
+
 func (v Version) Updated(level VersionLevel) Version {
 	result := v
 	switch level {
@@ -107,10 +126,25 @@ func (v Version) Updated(level VersionLevel) Version {
 	return result
 }
 
+func (v Version) SemVer() SemVer {
+	return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// String writes v in notation. Template notations are executed without TemplateContext,
+// and fall back to NotationDetail when they can not be executed.
 func (v Version) String(notation VersionNotation) string {
+	if notation.IsTemplate() {
+		if t, err := notation.Template(); err == nil {
+			if result, err := t.Execute(v, TemplateContext{}); err == nil {
+				return result
+			}
+		}
+		notation = NotationDetail
+	}
+
 	result := fmt.Sprintf("%d.%d", v.Major, v.Minor)
 	switch notation {
-	case NotationNormal:
+	case NotationNormal, NotationSemVer:
 		result += fmt.Sprintf(".%d", v.Patch)
 	case NotationDetail:
 		result += fmt.Sprintf(".%d.%d", v.Patch, v.Build)
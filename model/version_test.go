@@ -59,7 +59,7 @@ func TestParseVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseVersion(tt.input)
+			result, err := ParseVersion(tt.input)
 			if tt.hasError {
 				assert.Error(t, err)
 			} else {
@@ -139,6 +139,26 @@ func TestVersionUpdated(t *testing.T) {
 	}
 }
 
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  Version
+		other    Version
+		expected int
+	}{
+		{name: "equal", version: Version{Major: 1, Minor: 2, Patch: 3}, other: Version{Major: 1, Minor: 2, Patch: 3}, expected: 0},
+		{name: "lower major", version: Version{Major: 1, Minor: 9}, other: Version{Major: 2}, expected: -1},
+		{name: "higher patch", version: Version{Major: 1, Patch: 10}, other: Version{Major: 1, Patch: 9}, expected: 1},
+		{name: "higher build", version: Version{Major: 1, Build: 1}, other: Version{Major: 1}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.version.Compare(tt.other))
+		})
+	}
+}
+
 func TestVersionString(t *testing.T) {
 	version := Version{Major: 1, Minor: 2, Patch: 3, Build: 4}
 
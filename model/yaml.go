@@ -0,0 +1,230 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+type yamlCodec struct{}
+
+// stringPaths returns the dotted paths of the string values of Info.
+func stringPaths() (map[string]bool, error) {
+	values, err := leaves(Info{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value.raw[0] == '"' {
+			result[joinPath(value.path)] = true
+		}
+	}
+	return result, nil
+}
+
+func yamlParseErrorOf(err error) ParseError {
+	match := yamlErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return ParseError{Err: err}
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	return ParseError{Line: line, Err: errors.New(match[2])}
+}
+
+// yamlValueOf converts node into a value for JSON. Scalars at a path of strings are taken as written,
+// so that unquoted values such as 1.0 or 040004 are read as strings rather than numbers.
+func yamlValueOf(node *yaml.Node, path []string, stringKeys map[string]bool, positions map[string]position) (any, error) {
+	key := joinPath(path)
+	positions[key] = position{line: node.Line, column: node.Column}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return yamlValueOf(node.Content[0], path, stringKeys, positions)
+	case yaml.AliasNode:
+		return yamlValueOf(node.Alias, path, stringKeys, positions)
+	case yaml.MappingNode:
+		result := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			value, err := yamlValueOf(node.Content[i+1], append(path[:len(path):len(path)], name), stringKeys, positions)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = value
+		}
+		return result, nil
+	case yaml.ScalarNode:
+		if stringKeys[key] && node.Tag != "!!null" {
+			return node.Value, nil
+		}
+	}
+
+	var result any
+	if err := node.Decode(&result); err != nil {
+		return nil, ParseError{Line: node.Line, Column: node.Column, Err: err}
+	}
+	return result, nil
+}
+
+func joinPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+func (yamlCodec) Decode(data []byte) (Info, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return Info{}, yamlParseErrorOf(err)
+	}
+
+	stringKeys, err := stringPaths()
+	if err != nil {
+		return Info{}, err
+	}
+
+	positions := make(map[string]position)
+	value, err := yamlValueOf(&document, nil, stringKeys, positions)
+	if err != nil {
+		return Info{}, err
+	}
+	return infoOf(value, positions)
+}
+
+// yamlNodeOf returns the node of a JSON value.
+func yamlNodeOf(raw json.RawMessage) (*yaml.Node, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if number, ok := value.(json.Number); ok {
+		if integer, err := number.Int64(); err == nil {
+			value = integer
+		} else {
+			value, _ = number.Float64()
+		}
+	}
+
+	result := &yaml.Node{}
+	if err := result.Encode(value); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// yamlSet sets value at path under mapping. Missing keys are appended to their parent mapping,
+// and a replaced value keeps its comments and, while it stays a string, its style.
+func yamlSet(mapping *yaml.Node, path []string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+
+		current := mapping.Content[i+1]
+		switch {
+		case len(path) == 1:
+			style := current.Style
+			if current.Tag != "!!str" || value.Tag != "!!str" {
+				style = value.Style
+			}
+			current.Kind, current.Tag, current.Value, current.Content, current.Style = value.Kind, value.Tag, value.Value, value.Content, style
+		case current.Kind == yaml.MappingNode:
+			yamlSet(current, path[1:], value)
+		default:
+			*current = yaml.Node{Kind: yaml.MappingNode, HeadComment: current.HeadComment, LineComment: current.LineComment}
+			yamlSet(current, path[1:], value)
+		}
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		mapping.Content = append(mapping.Content, key, value)
+		return
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, key, child)
+	yamlSet(child, path[1:], value)
+}
+
+func yamlEncode(document *yaml.Node) ([]byte, error) {
+	var result bytes.Buffer
+	encoder := yaml.NewEncoder(&result)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return result.Bytes(), nil
+}
+
+func yamlSetLeaves(root *yaml.Node, values []leaf) error {
+	for _, value := range values {
+		node, err := yamlNodeOf(value.raw)
+		if err != nil {
+			return err
+		}
+		yamlSet(root, value.path, node)
+	}
+	return nil
+}
+
+func (yamlCodec) Encode(info Info) ([]byte, error) {
+	values, err := leaves(info)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if err = yamlSetLeaves(root, values); err != nil {
+		return nil, err
+	}
+	return yamlEncode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+}
+
+// Patch keeps comments and key order of original, while indentation is rewritten by two spaces.
+func (c yamlCodec) Patch(original []byte, info Info) ([]byte, error) {
+	if len(bytes.TrimSpace(original)) == 0 {
+		return c.Encode(info)
+	}
+
+	current, err := c.Decode(original)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := changedLeaves(current, info)
+	if err != nil || len(changed) == 0 {
+		return original, err
+	}
+
+	var document yaml.Node
+	if err = yaml.Unmarshal(original, &document); err != nil {
+		return nil, yamlParseErrorOf(err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, ParseError{Err: errors.New("version info must be a mapping")}
+	}
+
+	if err = yamlSetLeaves(document.Content[0], changed); err != nil {
+		return nil, err
+	}
+	return yamlEncode(&document)
+}
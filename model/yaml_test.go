@@ -0,0 +1,82 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLDecode(t *testing.T) {
+	info, err := yamlCodec{}.Decode([]byte(`# build metadata
+FixedFileInfo:
+  FileVersion: {Major: 1, Minor: 2}
+  FileOS: 040004
+StringFileInfo:
+  FileVersion: 1.2
+  CompanyName: &company Example Corp
+  ProductName: *company
+VarFileInfo:
+  Translation:
+    LangID: "0409"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.FixedFileInfo.FileVersion.Minor)
+	assert.Equal(t, "040004", info.FixedFileInfo.FileOS)
+	assert.Equal(t, "1.2", info.StringFileInfo.FileVersion)
+	assert.Equal(t, "Example Corp", info.StringFileInfo.ProductName)
+	assert.EqualValues(t, 0x0409, info.VarFileInfo.Translation.LangID)
+
+	tests := []struct {
+		name   string
+		data   string
+		line   int
+		column int
+	}{
+		{name: "syntax", data: "StringFileInfo:\n  FileVersion: a: b\n", line: 2},
+		{name: "type", data: "FixedFileInfo:\n  FileVersion:\n    Major: one\n", line: 3, column: 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := yamlCodec{}.Decode([]byte(tt.data))
+			var parseErr ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.line, parseErr.Line)
+			assert.Equal(t, tt.column, parseErr.Column)
+		})
+	}
+}
+
+func TestYAMLPatch(t *testing.T) {
+	original := []byte(`# version of the tool
+StringFileInfo:
+  FileVersion: "1.2.3" # released
+  CompanyName: Example Corp
+FixedFileInfo:
+  FileVersion:
+    Major: 1
+    Minor: 2
+    Patch: 3
+`)
+	info, err := yamlCodec{}.Decode(original)
+	require.NoError(t, err)
+
+	info = info.VersionUpdated(Version{Major: 1, Minor: 3}, Version{Major: 1, Minor: 3}, TargetFile, NotationNormal)
+	patched, err := yamlCodec{}.Patch(original, info)
+	require.NoError(t, err)
+	assert.Equal(t, `# version of the tool
+StringFileInfo:
+  FileVersion: "1.3.0" # released
+  CompanyName: Example Corp
+FixedFileInfo:
+  FileVersion:
+    Major: 1
+    Minor: 3
+    Patch: 0
+`, string(patched))
+
+	info.StringFileInfo.ProductName = "exevup"
+	patched, err = yamlCodec{}.Patch(original, info)
+	require.NoError(t, err)
+	assert.Contains(t, string(patched), "  CompanyName: Example Corp\n  ProductName: exevup\nFixedFileInfo:\n")
+}
@@ -0,0 +1,160 @@
+package peversion
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+// block is one node of the VS_VERSIONINFO tree: VS_VERSIONINFO itself, StringFileInfo, StringTable, String, VarFileInfo or Var.
+type block struct {
+	key      string
+	text     bool
+	value    []byte
+	children []block
+}
+
+func align(offset int) int {
+	return (offset + 3) &^ 3
+}
+
+func parseBlock(data []byte) (result block, length int, err error) {
+	if len(data) < 6 {
+		return block{}, 0, fmt.Errorf("%w: truncated block", ErrInvalidVersionInfo)
+	}
+
+	length = int(binary.LittleEndian.Uint16(data[0:]))
+	valueLength := int(binary.LittleEndian.Uint16(data[2:]))
+	result.text = binary.LittleEndian.Uint16(data[4:]) == 1
+	if length < 6 || length > len(data) {
+		return block{}, 0, fmt.Errorf("%w: invalid block length", ErrInvalidVersionInfo)
+	}
+	data = data[:length]
+
+	key, offset := decodeString(data, 6)
+	result.key = key
+	offset = align(offset)
+
+	if result.text {
+		//텍스트 값의 길이는 WORD 단위
+		valueLength *= 2
+	}
+	if offset+valueLength > length {
+		valueLength = max(length-offset, 0)
+	}
+	if offset < length {
+		result.value = data[offset : offset+valueLength]
+	}
+	offset = align(offset + valueLength)
+
+	for offset < length {
+		child, childLength, err := parseBlock(data[offset:])
+		if err != nil {
+			return block{}, 0, err
+		}
+		result.children = append(result.children, child)
+		offset = align(offset + childLength)
+	}
+
+	return result, length, nil
+}
+
+// decodeString decodes the null terminated UTF-16 string at offset and returns it with the offset following the terminator.
+func decodeString(data []byte, offset int) (string, int) {
+	var units []uint16
+	for offset+1 < len(data) {
+		unit := binary.LittleEndian.Uint16(data[offset:])
+		offset += 2
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+	return string(utf16.Decode(units)), offset
+}
+
+func (b block) child(key string) (block, bool) {
+	for _, child := range b.children {
+		if child.key == key {
+			return child, true
+		}
+	}
+	return block{}, false
+}
+
+func (b block) textValue() string {
+	value, _ := decodeString(b.value, 0)
+	return value
+}
+
+func fileVersionOf(ms uint32, ls uint32) goversioninfo.FileVersion {
+	return goversioninfo.FileVersion{
+		Major: int(ms >> 16),
+		Minor: int(ms & 0xFFFF),
+		Patch: int(ls >> 16),
+		Build: int(ls & 0xFFFF),
+	}
+}
+
+func parseFixedFileInfo(value []byte) (result goversioninfo.FixedFileInfo, err error) {
+	if len(value) < fixedFileInfoSize {
+		err = fmt.Errorf("%w: truncated VS_FIXEDFILEINFO", ErrInvalidVersionInfo)
+		return
+	}
+
+	var fields [fixedFileInfoSize / 4]uint32
+	for i := range fields {
+		fields[i] = binary.LittleEndian.Uint32(value[i*4:])
+	}
+	if fields[0] != fixedSignature {
+		err = fmt.Errorf("%w: invalid VS_FIXEDFILEINFO signature %08x", ErrInvalidVersionInfo, fields[0])
+		return
+	}
+
+	result.FileVersion = fileVersionOf(fields[2], fields[3])
+	result.ProductVersion = fileVersionOf(fields[4], fields[5])
+	result.FileFlagsMask = fmt.Sprintf("%02x", fields[6])
+	result.FileFlags = fmt.Sprintf("%02x", fields[7])
+	result.FileOS = fmt.Sprintf("%06x", fields[8])
+	result.FileType = fmt.Sprintf("%02x", fields[9])
+	result.FileSubType = fmt.Sprintf("%02x", fields[10])
+	return
+}
+
+// ParseVersionInfo decodes a raw VS_VERSIONINFO resource.
+// Only the first string table is read, as goversioninfo writes a single one.
+func ParseVersionInfo(data []byte) (result model.Info, err error) {
+	root, _, err := parseBlock(data)
+	if err != nil {
+		return
+	}
+	if root.key != "VS_VERSION_INFO" {
+		err = fmt.Errorf("%w: unexpected key %q", ErrInvalidVersionInfo, root.key)
+		return
+	}
+
+	if result.FixedFileInfo, err = parseFixedFileInfo(root.value); err != nil {
+		return
+	}
+
+	if stringFileInfo, ok := root.child("StringFileInfo"); ok && len(stringFileInfo.children) > 0 {
+		for _, str := range stringFileInfo.children[0].children {
+			//goversioninfo에서 다루지 않는 키는 무시
+			if field, err := model.ParseStringField(str.key); err == nil {
+				result, _ = result.StringFieldUpdated(field, str.textValue())
+			}
+		}
+	}
+
+	if varFileInfo, ok := root.child("VarFileInfo"); ok {
+		if translation, ok := varFileInfo.child("Translation"); ok && len(translation.value) >= 4 {
+			result.VarFileInfo.Translation.LangID = goversioninfo.LangID(binary.LittleEndian.Uint16(translation.value[0:]))
+			result.VarFileInfo.Translation.CharsetID = goversioninfo.CharsetID(binary.LittleEndian.Uint16(translation.value[2:]))
+		}
+	}
+
+	return
+}
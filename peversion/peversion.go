@@ -0,0 +1,115 @@
+// Package peversion reads the version resource of compiled Windows PE files (.exe, .dll) and COFF objects (.syso).
+package peversion
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+)
+
+var (
+	ErrNoResource         = errors.New("no resource section")
+	ErrNoVersionInfo      = errors.New("no version resource")
+	ErrInvalidVersionInfo = errors.New("invalid version resource")
+)
+
+const (
+	anyID             = -1
+	rtVersion         = 16
+	subdirectoryMask  = 0x80000000
+	fixedSignature    = 0xFEEF04BD
+	fixedFileInfoSize = 52
+)
+
+func Open(fileName string) (model.Info, error) {
+	file, err := pe.Open(fileName)
+	if err != nil {
+		return model.Info{}, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+func Parse(r io.ReaderAt) (model.Info, error) {
+	file, err := pe.NewFile(r)
+	if err != nil {
+		return model.Info{}, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+func Read(file *pe.File) (model.Info, error) {
+	section := file.Section(".rsrc")
+	if section == nil {
+		return model.Info{}, ErrNoResource
+	}
+
+	data, err := section.Data()
+	if err != nil {
+		return model.Info{}, err
+	}
+
+	resource, err := findVersionResource(data, section.VirtualAddress)
+	if err != nil {
+		return model.Info{}, err
+	}
+
+	return ParseVersionInfo(resource)
+}
+
+// findVersionResource walks the type, name and language levels of the resource directory in data
+// and returns the first RT_VERSION resource.
+func findVersionResource(data []byte, virtualAddress uint32) ([]byte, error) {
+	entry, err := directoryEntry(data, 0, rtVersion)
+	for level := 1; err == nil && level < 3; level++ {
+		if entry&subdirectoryMask == 0 {
+			return nil, fmt.Errorf("%w: unexpected data entry in resource directory", ErrInvalidVersionInfo)
+		}
+		entry, err = directoryEntry(data, entry&^subdirectoryMask, anyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if entry&subdirectoryMask != 0 || uint64(entry)+16 > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: invalid data entry", ErrInvalidVersionInfo)
+	}
+
+	address := binary.LittleEndian.Uint32(data[entry:])
+	size := binary.LittleEndian.Uint32(data[entry+4:])
+	//.syso 같은 오브젝트 파일은 섹션 주소가 0이므로 주소가 곧 섹션 내 오프셋
+	start := uint64(address) - uint64(virtualAddress)
+	if address < virtualAddress || start+uint64(size) > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: data entry out of section", ErrInvalidVersionInfo)
+	}
+
+	return data[start : start+uint64(size)], nil
+}
+
+// directoryEntry returns the OffsetToData of the first entry with the given id in the directory at offset.
+// Named entries have the high bit set in their id, so they only match anyID.
+func directoryEntry(data []byte, offset uint32, id int64) (uint32, error) {
+	if uint64(offset)+16 > uint64(len(data)) {
+		return 0, fmt.Errorf("%w: directory out of section", ErrInvalidVersionInfo)
+	}
+
+	count := int(binary.LittleEndian.Uint16(data[offset+12:])) + int(binary.LittleEndian.Uint16(data[offset+14:]))
+	entries := data[offset+16:]
+	if count*8 > len(entries) {
+		return 0, fmt.Errorf("%w: directory out of section", ErrInvalidVersionInfo)
+	}
+
+	for i := 0; i < count; i++ {
+		if name := binary.LittleEndian.Uint32(entries[i*8:]); id == anyID || int64(name) == id {
+			return binary.LittleEndian.Uint32(entries[i*8+4:]), nil
+		}
+	}
+	return 0, ErrNoVersionInfo
+}
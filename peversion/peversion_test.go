@@ -0,0 +1,125 @@
+package peversion
+
+import (
+	"debug/pe"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/josephspurrier/goversioninfo"
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInfo() model.Info {
+	info := model.DefaultInfo()
+	info = info.VersionUpdated(model.Version{Major: 1, Minor: 2, Patch: 3, Build: 4}, model.Version{Major: 1, Minor: 2}, model.TargetBoth, model.NotationDetail)
+	info.StringFileInfo.ProductVersion = "1.2.0-rc.1"
+	info.StringFileInfo.CompanyName = "Example Corp"
+	info.StringFileInfo.ProductName = "Example"
+	info.StringFileInfo.LegalCopyright = "Copyright (c) 2024"
+	info.StringFileInfo.OriginalFilename = "example.exe"
+	return info
+}
+
+func writeSyso(t *testing.T, info model.Info, fileName string) {
+	t.Helper()
+
+	vi := goversioninfo.VersionInfo(info)
+	vi.Build()
+	vi.Walk()
+	require.NoError(t, vi.WriteSyso(fileName, "amd64"))
+}
+
+func assertInfo(t *testing.T, expected model.Info, actual model.Info) {
+	t.Helper()
+
+	assert.Equal(t, expected.FixedFileInfo, actual.FixedFileInfo)
+	assert.Equal(t, expected.StringFileInfo, actual.StringFileInfo)
+	assert.Equal(t, expected.VarFileInfo, actual.VarFileInfo)
+}
+
+func TestOpenSyso(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "resource.syso")
+	info := testInfo()
+	writeSyso(t, info, fileName)
+
+	result, err := Open(fileName)
+	require.NoError(t, err)
+	assertInfo(t, info, result)
+}
+
+func TestOpenExecutable(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping cross compilation in short mode")
+	}
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is not available")
+	}
+
+	dir := t.TempDir()
+	info := testInfo()
+	writeSyso(t, info, filepath.Join(dir, "resource_windows_amd64.syso"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+
+	executable := filepath.Join(dir, "example.exe")
+	cmd := exec.Command(goBinary, "build", "-o", executable, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS=windows", "GOARCH=amd64", "CGO_ENABLED=0", "GOFLAGS=")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	result, err := Open(executable)
+	require.NoError(t, err)
+	assertInfo(t, info, result)
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	info := testInfo()
+	vi := goversioninfo.VersionInfo(info)
+	vi.Build()
+	vi.Walk()
+
+	t.Run("valid", func(t *testing.T) {
+		result, err := ParseVersionInfo(vi.Buffer.Bytes())
+		require.NoError(t, err)
+		assertInfo(t, info, result)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ParseVersionInfo(vi.Buffer.Bytes()[:40])
+		assert.ErrorIs(t, err, ErrInvalidVersionInfo)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		data := append([]byte{}, vi.Buffer.Bytes()...)
+		data[40] ^= 0xFF
+		_, err := ParseVersionInfo(data)
+		assert.ErrorIs(t, err, ErrInvalidVersionInfo)
+	})
+}
+
+func TestReadWithoutResource(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "resource.syso")
+	writeSyso(t, testInfo(), fileName)
+
+	file, err := pe.Open(fileName)
+	require.NoError(t, err)
+	defer file.Close()
+
+	file.Sections[0].Name = ".text"
+	_, err = Read(file)
+	assert.ErrorIs(t, err, ErrNoResource)
+}
+
+func TestFindVersionResource(t *testing.T) {
+	_, err := findVersionResource(make([]byte, 16), 0)
+	assert.ErrorIs(t, err, ErrNoVersionInfo)
+
+	_, err = findVersionResource(make([]byte, 8), 0)
+	assert.ErrorIs(t, err, ErrInvalidVersionInfo)
+}
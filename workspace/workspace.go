@@ -0,0 +1,201 @@
+// Package workspace reads .exevup.yaml, which declares one canonical version for the version info files
+// of products shipped together.
+//
+//	version: 1.4.0
+//	notation: normal
+//	files:
+//	  - path: cmd/client/versioninfo.json
+//	  - path: cmd/server/versioninfo.json
+//	    notation: detail
+//	    fields:
+//	      ProductName: Example Server
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"gopkg.in/yaml.v3"
+)
+
+const FileName = ".exevup.yaml"
+
+var (
+	ErrInvalidConfig = errors.New("invalid workspace config")
+)
+
+type Config struct {
+	// Version is the canonical version, written in the notation the files should follow unless they override it.
+	Version  string                `yaml:"version"`
+	Notation model.VersionNotation `yaml:"notation,omitempty"`
+	Files    []File                `yaml:"files"`
+}
+
+// File is a version info file following the canonical version. Path is relative to the config file.
+type File struct {
+	Path     string                `yaml:"path"`
+	Notation model.VersionNotation `yaml:"notation,omitempty"`
+	Fields   map[string]string     `yaml:"fields,omitempty"`
+}
+
+type Workspace struct {
+	FileName string
+	Config   Config
+	data     []byte
+}
+
+func validNotation(notation model.VersionNotation) bool {
+	switch notation {
+	case "", model.NotationSimple, model.NotationNormal, model.NotationDetail, model.NotationSemVer:
+		return true
+	}
+
+	_, err := notation.Template()
+	return err == nil
+}
+
+func (c Config) validate() error {
+	if _, err := model.ParseSemVer(c.Version); err != nil {
+		if _, err := model.ParseVersion(c.Version); err != nil || c.Version == "" {
+			return fmt.Errorf("%w: version %q", ErrInvalidConfig, c.Version)
+		}
+	}
+	if !validNotation(c.Notation) {
+		return fmt.Errorf("%w: notation %q", ErrInvalidConfig, c.Notation)
+	}
+
+	paths := make(map[string]bool, len(c.Files))
+	for i, file := range c.Files {
+		if file.Path == "" {
+			return fmt.Errorf("%w: files[%d] has no path", ErrInvalidConfig, i)
+		}
+		if paths[filepath.Clean(file.Path)] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidConfig, file.Path)
+		}
+		paths[filepath.Clean(file.Path)] = true
+		if !validNotation(file.Notation) {
+			return fmt.Errorf("%w: notation %q of %s", ErrInvalidConfig, file.Notation, file.Path)
+		}
+		for key := range file.Fields {
+			if _, err := model.ParseStringField(key); err != nil {
+				return fmt.Errorf("%w: %s of %s", ErrInvalidConfig, err, file.Path)
+			}
+		}
+	}
+	return nil
+}
+
+func Parse(data []byte) (result Config, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&result); err != nil {
+		err = fmt.Errorf("%w: %s", ErrInvalidConfig, err)
+		return
+	}
+	err = result.validate()
+	return
+}
+
+func Load(fileName string) (Workspace, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	config, err := Parse(data)
+	if err != nil {
+		return Workspace{}, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return Workspace{FileName: fileName, Config: config, data: data}, nil
+}
+
+func (w Workspace) Dir() string {
+	return filepath.Dir(w.FileName)
+}
+
+// Data returns the content of the config file.
+func (w Workspace) Data() []byte {
+	return w.data
+}
+
+// Path returns the path of file, resolved against the directory of the config file.
+func (w Workspace) Path(file File) string {
+	if filepath.IsAbs(file.Path) {
+		return file.Path
+	}
+	return filepath.Join(w.Dir(), filepath.FromSlash(file.Path))
+}
+
+// Notation returns the notation of file, which is the one of the workspace unless the file overrides it.
+// It is empty when neither gives one, meaning the notation in which the canonical version is written.
+func (w Workspace) Notation(file File) model.VersionNotation {
+	if file.Notation != "" {
+		return file.Notation
+	}
+	return w.Config.Notation
+}
+
+// VersionUpdated returns the content of the config file with only the canonical version replaced,
+// so that comments and formatting are kept.
+func (w Workspace) VersionUpdated(version string) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(w.data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: not a mapping", ErrInvalidConfig)
+	}
+
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "version" {
+			return replaceScalar(w.data, root.Content[i+1], version)
+		}
+	}
+	return nil, fmt.Errorf("%w: no version", ErrInvalidConfig)
+}
+
+// replaceScalar replaces the single line scalar of node in data by value, keeping its quotes.
+func replaceScalar(data []byte, node *yaml.Node, value string) ([]byte, error) {
+	offset := 0
+	for line := 1; line < node.Line; line++ {
+		next := bytes.IndexByte(data[offset:], '\n')
+		if next < 0 {
+			return nil, fmt.Errorf("%w: version out of file", ErrInvalidConfig)
+		}
+		offset += next + 1
+	}
+	start := offset + node.Column - 1
+
+	var end int
+	var text string
+	switch node.Style {
+	case 0:
+		end = start + len(node.Value)
+		text = value
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		quote := data[start]
+		closing := bytes.IndexByte(data[start+1:], quote)
+		if closing < 0 {
+			return nil, fmt.Errorf("%w: unterminated version", ErrInvalidConfig)
+		}
+		end = start + closing + 2
+		text = string(quote) + value + string(quote)
+	default:
+		return nil, fmt.Errorf("%w: version must be a single line", ErrInvalidConfig)
+	}
+
+	if end > len(data) || strings.TrimSpace(string(data[start:end])) == "" {
+		return nil, fmt.Errorf("%w: version out of file", ErrInvalidConfig)
+	}
+
+	result := make([]byte, 0, len(data)-(end-start)+len(text))
+	result = append(result, data[:start]...)
+	result = append(result, text...)
+	return append(result, data[end:]...), nil
+}
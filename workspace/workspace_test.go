@@ -0,0 +1,128 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simp7/goversioninfo-toolkit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Config
+		hasError bool
+	}{
+		{
+			name: "full",
+			data: `
+version: 1.4.0
+notation: normal
+files:
+  - path: cmd/client/versioninfo.json
+  - path: cmd/server/versioninfo.json
+    notation: detail
+    fields:
+      ProductName: Example Server
+`,
+			expected: Config{
+				Version:  "1.4.0",
+				Notation: model.NotationNormal,
+				Files: []File{
+					{Path: "cmd/client/versioninfo.json"},
+					{Path: "cmd/server/versioninfo.json", Notation: model.NotationDetail, Fields: map[string]string{"ProductName": "Example Server"}},
+				},
+			},
+		},
+		{
+			name:     "number like version",
+			data:     "version: 1.4\nfiles: []\n",
+			expected: Config{Version: "1.4", Files: []File{}},
+		},
+		{
+			name:     "semver",
+			data:     "version: 1.4.0-rc.1\n",
+			expected: Config{Version: "1.4.0-rc.1"},
+		},
+		{name: "missing version", data: "files: []\n", hasError: true},
+		{name: "invalid version", data: "version: one\n", hasError: true},
+		{
+			name:     "template notation",
+			data:     "version: 1.0.0\nfiles:\n  - path: a.json\n    notation: \"template:{{.Major}}.{{.Minor}} (build {{.Build}})\"\n",
+			expected: Config{Version: "1.0.0", Files: []File{{Path: "a.json", Notation: "template:{{.Major}}.{{.Minor}} (build {{.Build}})"}}},
+		},
+		{name: "invalid notation", data: "version: 1.0.0\nnotation: long\n", hasError: true},
+		{name: "invalid template notation", data: "version: 1.0.0\nnotation: \"template:{{.Major\"\n", hasError: true},
+		{name: "unknown key", data: "version: 1.0.0\nfile: []\n", hasError: true},
+		{name: "file without path", data: "version: 1.0.0\nfiles:\n  - notation: detail\n", hasError: true},
+		{name: "duplicated path", data: "version: 1.0.0\nfiles:\n  - path: a.json\n  - path: ./a.json\n", hasError: true},
+		{name: "unknown field", data: "version: 1.0.0\nfiles:\n  - path: a.json\n    fields:\n      Company: x\n", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.data))
+			if tt.hasError {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, FileName)
+	require.NoError(t, os.WriteFile(fileName, []byte("version: 1.0.0\nnotation: detail\nfiles:\n  - path: cmd/a/versioninfo.json\n  - path: cmd/b/versioninfo.json\n    notation: normal\n"), 0644))
+
+	w, err := Load(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, dir, w.Dir())
+	assert.Equal(t, filepath.Join(dir, "cmd", "a", "versioninfo.json"), w.Path(w.Config.Files[0]))
+	assert.Equal(t, model.NotationDetail, w.Notation(w.Config.Files[0]))
+	assert.Equal(t, model.NotationNormal, w.Notation(w.Config.Files[1]))
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestVersionUpdated(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "plain",
+			data:     "# shipped together\nversion: 1.0.0 # canonical\nfiles: []\n",
+			expected: "# shipped together\nversion: 1.10.0 # canonical\nfiles: []\n",
+		},
+		{
+			name:     "double quoted",
+			data:     "files: []\nversion:   \"1.0.0\"\n",
+			expected: "files: []\nversion:   \"1.10.0\"\n",
+		},
+		{
+			name:     "single quoted",
+			data:     "version: '1.0.0'",
+			expected: "version: '1.10.0'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte(tt.data))
+			require.NoError(t, err)
+
+			result, err := Workspace{Config: config, data: []byte(tt.data)}.VersionUpdated("1.10.0")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}